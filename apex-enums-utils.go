@@ -0,0 +1,298 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// apexEnum is an Enum schema: a closed set of strings or integers
+type apexEnum struct {
+	Name    string
+	Integer bool
+	Values  []string // Strings as they are, integers in decimal
+}
+
+// Splits enum values like "in-progress" or "IN_PROGRESS" into words for Go constant names
+var enumWordRegex = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+// parseApexEnum reads the values of an Enum schema, which must all be strings or all integers
+func parseApexEnum(schema Schema) (apexEnum, error) {
+	enum := apexEnum{Name: schema.Name}
+	if len(schema.Values) == 0 {
+		return enum, fmt.Errorf("enum %s has no values", schema.Name)
+	}
+
+	for i, raw := range schema.Values {
+		var text string
+		var number int64
+		switch {
+		case json.Unmarshal(raw, &text) == nil:
+			if i > 0 && enum.Integer {
+				return enum, fmt.Errorf("value %s is a string, but the other values are integers", raw)
+			}
+			enum.Values = append(enum.Values, text)
+		case json.Unmarshal(raw, &number) == nil:
+			if i > 0 && !enum.Integer {
+				return enum, fmt.Errorf("value %s is an integer, but the other values are strings", raw)
+			}
+			enum.Integer = true
+			enum.Values = append(enum.Values, strconv.FormatInt(number, 10))
+		default:
+			return enum, fmt.Errorf("value %s is neither a string nor an integer", raw)
+		}
+	}
+	return enum, nil
+}
+
+// findEnum returns the enum a field type refers to, if it's an Enum schema
+func findEnum(typeName string, allSchemas map[string]Schema) (apexEnum, bool) {
+	schema, exists := allSchemas[typeName]
+	if !exists || schema.Type != "Enum" {
+		return apexEnum{}, false
+	}
+	enum, err := parseApexEnum(schema)
+	return enum, err == nil
+}
+
+// literal is a value as a Go and TypeScript literal
+func (e apexEnum) literal(value string) string {
+	if e.Integer {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+func (e apexEnum) has(value string) bool {
+	for _, v := range e.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// goConstName is the Go constant of a value: StatusInProgress for "in-progress", Priority2 for
+// 2 and PriorityMinus1 for -1
+func (e apexEnum) goConstName(value string) string {
+	if e.Integer {
+		return e.Name + strings.Replace(value, "-", "Minus", 1)
+	}
+	name := e.Name
+	for _, word := range enumWordRegex.FindAllString(value, -1) {
+		name += capitalize(strings.ToLower(word))
+	}
+	return name
+}
+
+func (e apexEnum) goType() string {
+	if e.Integer {
+		return "int64"
+	}
+	return "string"
+}
+
+func (e apexEnum) tsType() string {
+	literals := make([]string, 0, len(e.Values))
+	for _, value := range e.Values {
+		literals = append(literals, e.literal(value))
+	}
+	return strings.Join(literals, " | ")
+}
+
+// goEnum generates the typed constants of an enum, a Valid method and an UnmarshalJSON that
+// rejects anything else
+func (e apexEnum) goEnum() string {
+	code := fmt.Sprintf("type %s %s\n\nconst (\n", e.Name, e.goType())
+	consts := make([]string, 0, len(e.Values))
+	for _, value := range e.Values {
+		code += fmt.Sprintf("  %s %s = %s\n", e.goConstName(value), e.Name, e.literal(value))
+		consts = append(consts, e.goConstName(value))
+	}
+	code += ")\n\n"
+
+	code += fmt.Sprintf("// Valid reports whether v is one of the values of %s\nfunc (v %s) Valid() bool {\n", e.Name, e.Name)
+	code += fmt.Sprintf("  switch v {\n  case %s:\n    return true\n  }\n  return false\n}\n\n", strings.Join(consts, ", "))
+
+	code += fmt.Sprintf("// UnmarshalJSON rejects values that aren't part of %s\nfunc (v *%s) UnmarshalJSON(data []byte) error {\n", e.Name, e.Name)
+	code += fmt.Sprintf("  var value %s\n", e.goType())
+	code += "  if err := json.Unmarshal(data, &value); err != nil {\n    return err\n  }\n"
+	code += fmt.Sprintf("  if !%s(value).Valid() {\n    return fmt.Errorf(\"invalid %s: %%v\", value)\n  }\n", e.Name, e.Name)
+	code += fmt.Sprintf("  *v = %s(value)\n  return nil\n}\n\n", e.Name)
+	return code
+}
+
+// unionVariants returns the discriminator values of a Union schema in order
+func unionVariants(schema Schema) []string {
+	keys := make([]string, 0, len(schema.Variants))
+	for key := range schema.Variants {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// goVariantField is the Go field holding one variant of a union: Card for "card"
+func goVariantField(key string) string {
+	name := ""
+	for _, word := range enumWordRegex.FindAllString(key, -1) {
+		name += capitalize(strings.ToLower(word))
+	}
+	return name
+}
+
+// tsUnion is a discriminated union of the variant schemas, each tagged with its discriminator
+func tsUnion(schema Schema) string {
+	ts := fmt.Sprintf("export type %s =\n", schema.Name)
+	for _, key := range unionVariants(schema) {
+		ts += fmt.Sprintf("  | ({ %s: %s } & %s)\n", schema.Discriminator, strconv.Quote(key), schema.Variants[key])
+	}
+	return strings.TrimSuffix(ts, "\n") + ";\n\n"
+}
+
+// goUnion generates a struct with the discriminator and a pointer per variant. Its JSON is the
+// variant's fields plus the discriminator, like in TypeScript.
+func goUnion(schema Schema) string {
+	discriminator := capitalize(schema.Discriminator)
+	variants := unionVariants(schema)
+
+	code := fmt.Sprintf("// %s is one of its variants, picked by %q\ntype %s struct {\n", schema.Name, schema.Discriminator, schema.Name)
+	code += fmt.Sprintf("  %s string `json:\"%s\"`\n", discriminator, schema.Discriminator)
+	for _, key := range variants {
+		code += fmt.Sprintf("  %s *%s `json:\"-\"`\n", goVariantField(key), schema.Variants[key])
+	}
+	code += "}\n\n"
+
+	code += fmt.Sprintf("func (u *%s) UnmarshalJSON(data []byte) error {\n", schema.Name)
+	code += fmt.Sprintf("  var head struct {\n    %s string `json:\"%s\"`\n  }\n", discriminator, schema.Discriminator)
+	code += "  if err := json.Unmarshal(data, &head); err != nil {\n    return err\n  }\n"
+	code += fmt.Sprintf("  *u = %s{%s: head.%s}\n  switch head.%s {\n", schema.Name, discriminator, discriminator, discriminator)
+	for _, key := range variants {
+		field := goVariantField(key)
+		code += fmt.Sprintf("  case %q:\n    u.%s = &%s{}\n    return json.Unmarshal(data, u.%s)\n", key, field, schema.Variants[key], field)
+	}
+	code += fmt.Sprintf("  }\n  return fmt.Errorf(\"invalid %s %s: %%q\", head.%s)\n}\n\n", schema.Name, schema.Discriminator, discriminator)
+
+	code += fmt.Sprintf("func (u %s) MarshalJSON() ([]byte, error) {\n  var variant interface{}\n  switch u.%s {\n", schema.Name, discriminator)
+	for _, key := range variants {
+		code += fmt.Sprintf("  case %q:\n    variant = u.%s\n", key, goVariantField(key))
+	}
+	code += fmt.Sprintf("  default:\n    return nil, fmt.Errorf(\"invalid %s %s: %%q\", u.%s)\n  }\n", schema.Name, schema.Discriminator, discriminator)
+	code += "  data, err := json.Marshal(variant)\n  if err != nil {\n    return nil, err\n  }\n"
+	code += "  fields := map[string]json.RawMessage{}\n  if err := json.Unmarshal(data, &fields); err != nil {\n    return nil, err\n  }\n"
+	code += "  if fields == nil {\n    fields = map[string]json.RawMessage{} // A nil variant\n  }\n"
+	code += fmt.Sprintf("  fields[%q], _ = json.Marshal(u.%s)\n  return json.Marshal(fields)\n}\n\n", schema.Discriminator, discriminator)
+	return code
+}
+
+// sqlEnumName is the Postgres type of an enum: "order_status" for OrderStatus
+func sqlEnumName(name string) string {
+	snake := ""
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			snake += "_"
+		}
+		snake += strings.ToLower(string(r))
+	}
+	return snake
+}
+
+// sqlStringEnums returns the string enums of apex.json. Integer enums are stored as integers and
+// have no Postgres type of their own.
+func sqlStringEnums(apex *ApexData) []apexEnum {
+	enums := []apexEnum{}
+	for _, schema := range apex.Schemas {
+		enum, err := parseApexEnum(schema)
+		if schema.Type == "Enum" && err == nil && !enum.Integer {
+			enums = append(enums, enum)
+		}
+	}
+	return enums
+}
+
+// sqlEnumStatements creates the Postgres type of an enum, or adds values missing from it. Each
+// statement runs on its own; values are never dropped.
+func sqlEnumStatements(enum apexEnum) []string {
+	typeName := postgresDialect{}.QuoteIdentifier(sqlEnumName(enum.Name))
+	labels := make([]string, 0, len(enum.Values))
+	for _, value := range enum.Values {
+		labels = append(labels, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+	}
+
+	statements := []string{fmt.Sprintf("DO $$ BEGIN\n  CREATE TYPE %s AS ENUM (%s);\nEXCEPTION WHEN duplicate_object THEN NULL;\nEND $$;", typeName, strings.Join(labels, ", "))}
+	for _, label := range labels {
+		statements = append(statements, fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s;", typeName, label))
+	}
+	return statements
+}
+
+// sqlEnumColumns swaps column types naming a string enum of apex.json (e.g. "OrderStatus") for
+// its Postgres type, so tables can use the types generateSQLEnums declares
+func sqlEnumColumns(apex *ApexData, columns map[string]string) (map[string]string, []apexEnum) {
+	enums := make(map[string]apexEnum)
+	for _, enum := range sqlStringEnums(apex) {
+		enums[enum.Name] = enum
+	}
+
+	resolved := make(map[string]string, len(columns))
+	used := []apexEnum{}
+	for name, columnType := range columns {
+		enum, isEnum := enums[strings.TrimSpace(columnType)]
+		if !isEnum {
+			resolved[name] = columnType
+			continue
+		}
+		resolved[name] = postgresDialect{}.QuoteIdentifier(sqlEnumName(enum.Name))
+		used = append(used, enum)
+	}
+	return resolved, used
+}
+
+// applySQLEnums creates or extends the Postgres types of enums in a database
+func applySQLEnums(db *sql.DB, enums []apexEnum) error {
+	for _, enum := range enums {
+		for _, statement := range sqlEnumStatements(enum) {
+			if _, err := db.Exec(statement); err != nil {
+				return fmt.Errorf("enum %s: %v", enum.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// generateSQLEnums writes the string enums as Postgres enum types to genesis/db/enums.sql of
+// the server, for the server's migrations to run. It can be run again after adding values.
+// Genesis applies the types itself when CreateTable uses an enum as a column type.
+func generateSQLEnums(apex *ApexData, projectDir, subDir string) error {
+	filePath := filepath.Join(projectDir, subDir, fmt.Sprintf("%s-star/genesis", subDir), "db", "enums.sql")
+
+	script := ""
+	for _, enum := range sqlStringEnums(apex) {
+		script += fmt.Sprintf("-- %s\n%s\n\n", enum.Name, strings.Join(sqlEnumStatements(enum), "\n"))
+	}
+
+	if script == "" {
+		// Don't leave types behind for enums that were removed
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	script = "-- Auto-generated Postgres enum types from apex.json. Run before creating tables that use them.\n\n" + script
+	if err := ensureDir(filepath.Dir(filePath)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, []byte(script), 0644); err != nil {
+		fmt.Println("❌ Failed to write enum types:", err)
+		return err
+	}
+	fmt.Println("✅ Successfully generated Postgres enum types at:", filePath)
+	return nil
+}
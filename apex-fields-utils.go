@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// apexField is a schema field in any of the forms apex.json allows:
+//
+//	"string"                                            a primitive (see apexPrimitives) or schema name
+//	{"type": "array", "arrayType": "User"}              an array of one
+//	{"type": "map", "valueType": "number"}              an object with string keys and values of one
+//	{"type": "number", "nullable": true, "default": 1}  either, with modifiers
+//	{"type": "string", "const": "card"}                 a literal, always that value
+//
+// Whether a field is optional comes from the schema's required list, or "optional": true.
+type apexField struct {
+	Name      string          `json:"-"`
+	Type      string          `json:"type"`
+	ArrayType string          `json:"arrayType,omitempty"`
+	ValueType string          `json:"valueType,omitempty"`
+	Optional  bool            `json:"optional,omitempty"`
+	Nullable  bool            `json:"nullable,omitempty"`
+	Default   json.RawMessage `json:"default,omitempty"`
+	Const     json.RawMessage `json:"const,omitempty"`
+}
+
+// apexFieldKeys are the keys the object form of a field may have
+var apexFieldKeys = toSet([]string{"type", "arrayType", "valueType", "optional", "nullable", "default", "const"})
+
+func parseApexField(value json.RawMessage) (apexField, error) {
+	var typeName string
+	if err := json.Unmarshal(value, &typeName); err == nil {
+		return apexField{Type: typeName}, nil
+	}
+
+	var field apexField
+	if err := json.Unmarshal(value, &field); err != nil || field.Type == "" {
+		return apexField{}, fmt.Errorf("field type must be a type name or an object with a type")
+	}
+	return field, nil
+}
+
+// parseSchemaFields returns the fields of a schema in name order. Fields missing from the
+// required list are optional, except path parameters which are always there. Enum and Union
+// schemas have none.
+func parseSchemaFields(schema Schema) ([]apexField, error) {
+	var raw map[string]json.RawMessage
+	if len(schema.Fields) == 0 {
+		return []apexField{}, nil
+	}
+	if err := json.Unmarshal(schema.Fields, &raw); err != nil {
+		return nil, err
+	}
+
+	required := toSet(schema.Required)
+	fields := make([]apexField, 0, len(raw))
+	for name, value := range raw {
+		field, err := parseApexField(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		field.Name = name
+		field.Optional = field.Optional || (!required[name] && schema.Type != "Path")
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields, nil
+}
+
+// schemaField finds one field of a schema
+func schemaField(schema Schema, name string) (apexField, bool) {
+	fields, _ := parseSchemaFields(schema)
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return apexField{}, false
+}
+
+func (f apexField) isArray() bool {
+	return f.Type == "array"
+}
+
+func (f apexField) isMap() bool {
+	return f.Type == "map"
+}
+
+// hasDefault reports whether the field has a default. A null default is the same as none, and
+// a literal is its own default.
+func (f apexField) hasDefault() bool {
+	return len(f.defaultValue()) > 0 && string(f.defaultValue()) != "null"
+}
+
+func (f apexField) isLiteral() bool {
+	return len(f.Const) > 0
+}
+
+// defaultValue is the JSON the field defaults to: its literal or its default
+func (f apexField) defaultValue() json.RawMessage {
+	if f.isLiteral() {
+		return f.Const
+	}
+	return f.Default
+}
+
+// elementType is the type of the field, of its items for arrays or of its values for maps
+func (f apexField) elementType() string {
+	if f.isArray() {
+		return f.ArrayType
+	}
+	if f.isMap() {
+		return f.ValueType
+	}
+	return f.Type
+}
+
+// tsType is the TypeScript type of the field, without the ? of optional fields
+func (f apexField) tsType() string {
+	ts := f.elementType()
+	if primitive, ok := apexPrimitives[ts]; ok {
+		ts = primitive.tsType
+	}
+	if f.isLiteral() {
+		var literal interface{}
+		json.Unmarshal(f.Const, &literal)
+		compact, _ := json.Marshal(literal)
+		ts = string(compact)
+	}
+	if f.isArray() {
+		ts += "[]"
+	}
+	if f.isMap() {
+		ts = fmt.Sprintf("Record<string, %s>", ts)
+	}
+	if f.Nullable {
+		ts += " | null"
+	}
+	return ts
+}
+
+// goPointer reports whether the Go field needs a pointer to tell "not set" or null apart from
+// the zero value. Slices, maps and raw JSON are nil already, and defaults make "not set"
+// impossible.
+func (f apexField) goPointer() bool {
+	return !f.isArray() && !f.isMap() && f.Type != "json" && (f.Nullable || (f.Optional && !f.hasDefault()))
+}
+
+// goType is the Go type of the field; unknown types fall back to interface{}
+func (f apexField) goType(allSchemas map[string]Schema) string {
+	goType := f.elementType()
+	if primitive, ok := apexPrimitives[goType]; ok {
+		goType = primitive.goType
+	} else if _, exists := allSchemas[goType]; !exists {
+		return "interface{}"
+	}
+
+	if f.isArray() {
+		return "[]" + goType
+	}
+	if f.isMap() {
+		return "map[string]" + goType
+	}
+	if f.goPointer() {
+		return "*" + goType
+	}
+	return goType
+}
+
+// goTag is the json struct tag of the field
+func (f apexField) goTag() string {
+	if f.Optional && !f.hasDefault() {
+		return fmt.Sprintf("`json:\"%s,omitempty\"`", f.Name)
+	}
+	return fmt.Sprintf("`json:\"%s\"`", f.Name)
+}
+
+// goDefault is the default as an untyped Go constant, or "" if the field has none or it
+// doesn't match the field's type. Enum defaults must be one of the enum's values.
+func (f apexField) goDefault(allSchemas map[string]Schema) string {
+	if !f.hasDefault() || f.isArray() || f.isMap() {
+		return ""
+	}
+	value := f.defaultValue()
+
+	primitive, isPrimitive := apexPrimitives[f.Type]
+	switch {
+	case primitive.literal == "string":
+		var text string
+		if json.Unmarshal(value, &text) == nil && (primitive.valid == nil || primitive.valid(text)) {
+			return strconv.Quote(text)
+		}
+	case primitive.literal == "number":
+		var number float64
+		if json.Unmarshal(value, &number) == nil {
+			return strconv.FormatFloat(number, 'g', -1, 64)
+		}
+	case primitive.literal == "integer":
+		var number int64
+		if json.Unmarshal(value, &number) == nil {
+			return strconv.FormatInt(number, 10)
+		}
+	case primitive.literal == "boolean":
+		var boolean bool
+		if json.Unmarshal(value, &boolean) == nil {
+			return strconv.FormatBool(boolean)
+		}
+	case !isPrimitive:
+		enum, ok := findEnum(f.Type, allSchemas)
+		if !ok {
+			return ""
+		}
+		var text string
+		var number int64
+		if !enum.Integer && json.Unmarshal(value, &text) == nil && enum.has(text) {
+			return enum.literal(text)
+		}
+		if enum.Integer && json.Unmarshal(value, &number) == nil && enum.has(strconv.FormatInt(number, 10)) {
+			return enum.literal(strconv.FormatInt(number, 10))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+const (
+	apexHistoryLimit      = 100 // Revisions kept per project, oldest dropped first
+	apexHistoryTimeFormat = "20060102-150405.000000"
+)
+
+// Matches a history file: <UTC save time>-<revision>.json
+var apexHistoryNameRegex = regexp.MustCompile(`^(\d{8}-\d{6}\.\d{6})-([0-9a-f]+)\.json$`)
+
+type apexHistoryEntry struct {
+	path     string
+	revision string
+	savedAt  time.Time
+}
+
+// getApexHistoryDir is where every saved apex.json is kept. It lives in .genesis so it never
+// ends up in commits.
+func getApexHistoryDir(projectPath string) string {
+	return filepath.Join(getGenesisDir(projectPath), "apex-history")
+}
+
+// listApexHistory returns the saved revisions, oldest first
+func listApexHistory(projectPath string) ([]apexHistoryEntry, error) {
+	historyDir := getApexHistoryDir(projectPath)
+	files, err := os.ReadDir(historyDir)
+	if os.IsNotExist(err) {
+		return []apexHistoryEntry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	entries := []apexHistoryEntry{}
+	for _, file := range files {
+		match := apexHistoryNameRegex.FindStringSubmatch(file.Name())
+		if match == nil {
+			continue
+		}
+		savedAt, err := time.Parse(apexHistoryTimeFormat, match[1])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, apexHistoryEntry{path: filepath.Join(historyDir, file.Name()), revision: match[2], savedAt: savedAt})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].savedAt.Before(entries[j].savedAt) })
+	return entries, nil
+}
+
+// recordApexRevision adds apex.json content to the history unless it's already the latest
+// entry, then drops the oldest entries beyond apexHistoryLimit. The caller holds apex.json's
+// lock.
+func recordApexRevision(projectPath string, data []byte) error {
+	entries, err := listApexHistory(projectPath)
+	if err != nil {
+		return err
+	}
+
+	revision := fileRevision(data)
+	if len(entries) > 0 && entries[len(entries)-1].revision == revision {
+		return nil
+	}
+
+	historyDir := getApexHistoryDir(projectPath)
+	if err := ensureDir(historyDir); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.json", time.Now().UTC().Format(apexHistoryTimeFormat), revision)
+	if err := writeFileAtomic(filepath.Join(historyDir, name), data, 0644); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(entries)+1-apexHistoryLimit; i++ {
+		os.Remove(entries[i].path)
+	}
+	return nil
+}
+
+// readApexRevision loads one revision from the history, "" being the current apex.json
+func readApexRevision(projectPath, revision string) (*ApexData, error) {
+	if revision == "" {
+		return getApexData(projectPath)
+	}
+
+	entries, err := listApexHistory(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.revision != revision {
+			continue
+		}
+
+		data, err := os.ReadFile(entry.path)
+		if err != nil {
+			return nil, err
+		}
+		// Entries are saved at the version current back then
+		data, _, err = migrateDocument(entry.path, data, apexMigrations)
+		if err != nil {
+			return nil, err
+		}
+		var apexData ApexData
+		if err := json.Unmarshal(data, &apexData); err != nil {
+			return nil, fmt.Errorf("❌ Failed to parse revision %s: %v", revision, err)
+		}
+		return &apexData, nil
+	}
+	return nil, fmt.Errorf("❌ Revision %s is not in the apex.json history", revision)
+}
+
+// diffApex lists the endpoints, schemas and operations that differ between two revisions,
+// section by section in name order
+func diffApex(from, to *ApexData) []ApexChange {
+	changes := []ApexChange{}
+	diffSection := func(section string, before, after map[string]interface{}) {
+		names := []string{}
+		for name := range before {
+			names = append(names, name)
+		}
+		for name := range after {
+			if _, exists := before[name]; !exists {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			oldItem, hadItem := before[name]
+			newItem, hasItem := after[name]
+			switch {
+			case !hadItem:
+				changes = append(changes, ApexChange{Section: section, Name: name, Change: "added"})
+			case !hasItem:
+				changes = append(changes, ApexChange{Section: section, Name: name, Change: "removed"})
+			default:
+				oldJSON, _ := json.Marshal(oldItem)
+				newJSON, _ := json.Marshal(newItem)
+				if !bytes.Equal(oldJSON, newJSON) {
+					changes = append(changes, ApexChange{Section: section, Name: name, Change: "changed"})
+				}
+			}
+		}
+	}
+
+	diffSection("endpoints", endpointsByPath(from.Endpoints), endpointsByPath(to.Endpoints))
+	diffSection("schemas", schemasByName(from.Schemas), schemasByName(to.Schemas))
+	diffSection("operations", operationsByName(from.Operations), operationsByName(to.Operations))
+	return changes
+}
+
+func endpointsByPath(endpoints []Endpoint) map[string]interface{} {
+	byPath := make(map[string]interface{})
+	for _, endpoint := range endpoints {
+		byPath[endpoint.Path] = endpoint
+	}
+	return byPath
+}
+
+func schemasByName(schemas []Schema) map[string]interface{} {
+	byName := make(map[string]interface{})
+	for _, schema := range schemas {
+		// Compare fields by content rather than formatting
+		var fields interface{}
+		json.Unmarshal(schema.Fields, &fields)
+		byName[schema.Name] = []interface{}{schema.Type, fields, schema.Required, schema.Values, schema.Discriminator, schema.Variants}
+	}
+	return byName
+}
+
+func operationsByName(operations []Operation) map[string]interface{} {
+	byName := make(map[string]interface{})
+	for _, operation := range operations {
+		byName[operation.Name] = operation
+	}
+	return byName
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ApexRevision is one saved version of apex.json
+type ApexRevision struct {
+	Revision   string `json:"revision"`
+	SavedAt    string `json:"savedAt"` // RFC3339
+	Endpoints  int    `json:"endpoints"`
+	Schemas    int    `json:"schemas"`
+	Operations int    `json:"operations"`
+	Current    bool   `json:"current"` // Matches apex.json as it is now
+}
+
+// ApexChange is an endpoint, schema or operation that differs between two revisions
+type ApexChange struct {
+	Section string `json:"section"` // "endpoints", "schemas" or "operations"
+	Name    string `json:"name"`    // Path of an endpoint, name of a schema or operation
+	Change  string `json:"change"`  // "added", "removed" or "changed"
+}
+
+// ApexDiff is what changed going from one revision to another
+type ApexDiff struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Changes []ApexChange `json:"changes"`
+}
+
+// GetApexHistory lists the revisions of apex.json saved by Genesis, newest first. Only the last
+// apexHistoryLimit are kept.
+func (a *App) GetApexHistory(dir string) ([]ApexRevision, error) {
+	if err := validateProjectDir(dir); err != nil {
+		return nil, err
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+
+	entries, err := listApexHistory(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to read apex.json history: %v", err)
+	}
+
+	current := ""
+	if data, err := os.ReadFile(filepath.Join(projectPath, "apex.json")); err == nil {
+		current = fileRevision(data)
+	}
+
+	revisions := []ApexRevision{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		revision := ApexRevision{
+			Revision: entries[i].revision,
+			SavedAt:  entries[i].savedAt.Local().Format(time.RFC3339),
+			Current:  entries[i].revision == current,
+		}
+		if apexData, err := readApexRevision(projectPath, entries[i].revision); err == nil {
+			revision.Endpoints = len(apexData.Endpoints)
+			revision.Schemas = len(apexData.Schemas)
+			revision.Operations = len(apexData.Operations)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// DiffApexRevisions compares two revisions from the history. An empty revision stands for
+// apex.json as it is now.
+func (a *App) DiffApexRevisions(dir, from, to string) (*ApexDiff, error) {
+	if err := validateProjectDir(dir); err != nil {
+		return nil, err
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+
+	fromData, err := readApexRevision(projectPath, from)
+	if err != nil {
+		return nil, err
+	}
+	toData, err := readApexRevision(projectPath, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApexDiff{From: from, To: to, Changes: diffApex(fromData, toData)}, nil
+}
+
+// RestoreApexRevision saves a revision from the history as apex.json and returns the new
+// revision. The apex.json it replaces stays in the history, so a restore can be undone too.
+func (a *App) RestoreApexRevision(dir, revision string) (string, error) {
+	if err := validateProjectDir(dir); err != nil {
+		return "", err
+	}
+	if revision == "" {
+		return "", fmt.Errorf("❌ Revision cannot be empty")
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+
+	apexData, err := readApexRevision(projectPath, revision)
+	if err != nil {
+		return "", err
+	}
+
+	saved, err := a.SaveApexDocument(dir, *apexData, "")
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("⏪ Restored apex.json of %s to revision %s\n", dir, revision)
+	return saved, nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// apexPrimitive is a built-in field type and how it maps to Go and TypeScript
+type apexPrimitive struct {
+	goType   string            // Type in the generated api package
+	tsType   string            // Type in the generated types.ts
+	literal  string            // JSON kind of defaults: "string", "number", "integer" or "boolean"; "" for no defaults
+	format   string            // @format of the TypeScript field, for strings with a format
+	valid    func(string) bool // Checks string defaults; nil accepts any string
+	goParse  string            // Parses a raw path or query parameter into (value, error); "" when the string is the value
+	goImport string            // Package goParse and goType need
+	goZero   string            // Zero value in generated handlers; "" leaves the field out
+}
+
+var apexPrimitives = map[string]apexPrimitive{
+	"string":    {goType: "string", tsType: "string", literal: "string", goZero: `""`},
+	"number":    {goType: "float64", tsType: "number", literal: "number", goParse: "strconv.ParseFloat(%s, 64)", goImport: "strconv", goZero: "0.0"},
+	"integer":   {goType: "int64", tsType: "number", literal: "integer", goParse: "strconv.ParseInt(%s, 10, 64)", goImport: "strconv", goZero: "0"},
+	"boolean":   {goType: "bool", tsType: "boolean", literal: "boolean", goParse: "strconv.ParseBool(%s)", goImport: "strconv", goZero: "false"},
+	"date-time": {goType: "time.Time", tsType: "string", format: "date-time", goParse: "time.Parse(time.RFC3339, %s)", goImport: "time"},
+	"date":      {goType: "string", tsType: "string", literal: "string", format: "date", valid: validDate, goParse: "api.ParseDate(%s)", goZero: `""`},
+	"uuid":      {goType: "string", tsType: "string", literal: "string", format: "uuid", valid: validUUID, goParse: "api.ParseUUID(%s)", goZero: `""`},
+	"email":     {goType: "string", tsType: "string", literal: "string", format: "email", valid: validEmail, goParse: "api.ParseEmail(%s)", goZero: `""`},
+	"url":       {goType: "string", tsType: "string", literal: "string", format: "url", valid: validURL, goParse: "api.ParseURL(%s)", goZero: `""`},
+	"decimal":   {goType: "string", tsType: "string", literal: "string", format: "decimal", valid: validDecimal, goParse: "api.ParseDecimal(%s)", goZero: `""`},
+	"json":      {goType: "json.RawMessage", tsType: "unknown", goImport: "encoding/json"},
+}
+
+// primitiveNames lists the built-in field types in a stable order, for messages
+func primitiveNames() string {
+	names := make([]string, 0, len(apexPrimitives))
+	for name := range apexPrimitives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// The checks below match the Parse functions of goFormats
+var (
+	uuidRegex    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	decimalRegex = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+)
+
+func validDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+func validUUID(s string) bool {
+	return uuidRegex.MatchString(s)
+}
+
+func validEmail(s string) bool {
+	address, err := mail.ParseAddress(s)
+	return err == nil && address.Address == s
+}
+
+func validURL(s string) bool {
+	u, err := url.ParseRequestURI(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func validDecimal(s string) bool {
+	return decimalRegex.MatchString(s)
+}
+
+// goFormatFuncs are the functions goFormats adds to the api package; schemas can't use the names
+var goFormatFuncs = toSet([]string{"ParseDate", "ParseUUID", "ParseEmail", "ParseURL", "ParseDecimal"})
+
+// goFormats is api/formats.go: the checks generated handlers run on path and query parameters
+// of string types with a format
+const goFormats = `package api
+
+import (
+  "fmt"
+  "net/mail"
+  "net/url"
+  "regexp"
+  "time"
+)
+
+var (
+  uuidRegex    = regexp.MustCompile(` + "`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`" + `)
+  decimalRegex = regexp.MustCompile(` + "`^-?[0-9]+(\\.[0-9]+)?$`" + `)
+)
+
+// ParseDate checks s is a date like 2006-01-02
+func ParseDate(s string) (string, error) {
+  if _, err := time.Parse("2006-01-02", s); err != nil {
+    return "", fmt.Errorf("invalid date: %q", s)
+  }
+  return s, nil
+}
+
+// ParseUUID checks s is a UUID like 123e4567-e89b-12d3-a456-426614174000
+func ParseUUID(s string) (string, error) {
+  if !uuidRegex.MatchString(s) {
+    return "", fmt.Errorf("invalid uuid: %q", s)
+  }
+  return s, nil
+}
+
+// ParseEmail checks s is a bare email address
+func ParseEmail(s string) (string, error) {
+  address, err := mail.ParseAddress(s)
+  if err != nil || address.Address != s {
+    return "", fmt.Errorf("invalid email: %q", s)
+  }
+  return s, nil
+}
+
+// ParseURL checks s is an absolute URL
+func ParseURL(s string) (string, error) {
+  u, err := url.ParseRequestURI(s)
+  if err != nil || u.Scheme == "" || u.Host == "" {
+    return "", fmt.Errorf("invalid url: %q", s)
+  }
+  return s, nil
+}
+
+// ParseDecimal checks s is a decimal number like -12.50, kept as a string so it stays exact
+func ParseDecimal(s string) (string, error) {
+  if !decimalRegex.MatchString(s) {
+    return "", fmt.Errorf("invalid decimal: %q", s)
+  }
+  return s, nil
+}
+`
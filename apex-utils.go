@@ -6,55 +6,55 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 func getApexData(projectDir string) (*ApexData, error) {
 	apexFilePath := filepath.Join(projectDir, "apex.json")
+	unlock := lockFile(apexFilePath)
+	defer unlock()
 
+	apexData, _, err := loadApexUnlocked(apexFilePath)
+	return apexData, err
+}
+
+// loadApexUnlocked reads apex.json and its revision, saving it back first if it needed a
+// migration. The caller holds the file's lock.
+func loadApexUnlocked(apexFilePath string) (*ApexData, string, error) {
 	fmt.Println("📖 Checking for apex.json in:", apexFilePath)
 
 	// Read apex.json file
 	data, err := os.ReadFile(apexFilePath)
 	if err != nil {
 		fmt.Println("❌ Error reading apex.json:", err)
-		return nil, err
+		return nil, "", err
+	}
+	revision := fileRevision(data)
+
+	data, migrated, err := migrateDocument(apexFilePath, data, apexMigrations)
+	if err != nil {
+		fmt.Println("❌ Error migrating apex.json:", err)
+		return nil, "", err
 	}
 
 	// Parse JSON into ApexData struct
 	var apexData ApexData
 	if err := json.Unmarshal(data, &apexData); err != nil {
 		fmt.Println("❌ Error parsing apex.json:", err)
-		return nil, err
+		return nil, "", err
 	}
 
-	fmt.Println("✅ Successfully loaded APEX data for project:", projectDir)
-	return &apexData, nil
-}
-
-func tsType(value json.RawMessage) string {
-	// Try to unmarshal into a string (for primitive types)
-	var primitiveType string
-	if err := json.Unmarshal(value, &primitiveType); err == nil {
-		switch primitiveType {
-		case "string", "number", "boolean":
-			return primitiveType
-		default:
-			// Assume it's a reference to another schema
-			return primitiveType
+	if migrated {
+		if saved, err := writeJSONUnlocked(apexFilePath, apexData); err != nil {
+			fmt.Println("⚠️ Failed to save migrated apex.json:", err)
+		} else {
+			revision = saved
 		}
 	}
 
-	// Try to unmarshal into an object that represents an array
-	var arrayType struct {
-		Type      string `json:"type"`
-		ArrayType string `json:"arrayType"`
-	}
-	if err := json.Unmarshal(value, &arrayType); err == nil && arrayType.Type == "array" {
-		return tsType(json.RawMessage(`"`+arrayType.ArrayType+`"`)) + "[]"
-	}
-
-	return "any" // Fallback for unknown types
+	fmt.Println("✅ Successfully loaded APEX data from:", apexFilePath)
+	return &apexData, revision, nil
 }
 
 // generateTSTypes creates TypeScript interfaces from the APEX schema.
@@ -70,17 +70,41 @@ func generateTSTypes(apex *ApexData, projectDir, subDir string) error {
 	// Generate TypeScript types
 	ts := "/* Auto-generated TypeScript Types */\n\n"
 	for _, schema := range apex.Schemas {
-		ts += fmt.Sprintf("export type %s = {\n", schema.Name)
+		switch schema.Type {
+		case "Enum":
+			if enum, err := parseApexEnum(schema); err == nil {
+				ts += fmt.Sprintf("export type %s = %s;\n\n", schema.Name, enum.tsType())
+			}
+			continue
+		case "Union":
+			ts += tsUnion(schema)
+			continue
+		}
 
 		// Parse fields from JSON
-		var fields map[string]json.RawMessage
-		if err := json.Unmarshal(schema.Fields, &fields); err != nil {
+		fields, err := parseSchemaFields(schema)
+		if err != nil {
 			fmt.Println("⚠️ Failed to parse fields for schema:", schema.Name, err)
 			continue
 		}
 
-		for key, value := range fields {
-			ts += fmt.Sprintf("  %s: %s;\n", key, tsType(value))
+		ts += fmt.Sprintf("export type %s = {\n", schema.Name)
+		for _, field := range fields {
+			tags := []string{}
+			if format := apexPrimitives[field.elementType()].format; format != "" {
+				tags = append(tags, "@format "+format)
+			}
+			if field.hasDefault() && !field.isLiteral() {
+				tags = append(tags, fmt.Sprintf("@default %s", field.Default))
+			}
+			if len(tags) > 0 {
+				ts += fmt.Sprintf("  /** %s */\n", strings.Join(tags, " "))
+			}
+			optional := ""
+			if field.Optional {
+				optional = "?"
+			}
+			ts += fmt.Sprintf("  %s%s: %s;\n", field.Name, optional, field.tsType())
 		}
 		ts += "}\n\n"
 	}
@@ -116,6 +140,29 @@ func tsMethod(method string) string {
 	}
 }
 
+// Matches a path parameter of an endpoint, e.g. {id} in /api/v1/users/{id}
+var pathParamRegex = regexp.MustCompile(`\{([^{}/]*)\}`)
+
+// pathParams lists the parameter names of an endpoint path, in order
+func pathParams(endpoint string) []string {
+	params := []string{}
+	for _, match := range pathParamRegex.FindAllStringSubmatch(endpoint, -1) {
+		params = append(params, match[1])
+	}
+	return params
+}
+
+// tsPathLiteral quotes an endpoint for the TS client, interpolating path parameters from the
+// `path` argument
+func tsPathLiteral(endpoint string) string {
+	if !pathParamRegex.MatchString(endpoint) {
+		return "'" + endpoint + "'"
+	}
+	return "`" + pathParamRegex.ReplaceAllStringFunc(endpoint, func(param string) string {
+		return "${encodeURIComponent(path." + strings.Trim(param, "{}") + ")}"
+	}) + "`"
+}
+
 // generateTSQueries creates a queries.ts file for react-query hooks using APEX
 func generateTSQueries(apex *ApexData, projectDir, subDir string) error {
 	projectPath := filepath.Join(projectDir, subDir)
@@ -149,14 +196,25 @@ import APEX from './apex';
 		queryKeyFunc := fmt.Sprintf("get%sQueryKey", op.Name)
 		queryKeyType := fmt.Sprintf("%sQueryKey", op.Name)
 
-		paramsDef := ""
-		paramsArg := ""
+		paramsDefs := []string{}
+		paramsArgs := []string{}
+		if op.PathSchema != "" {
+			paramsDefs = append(paramsDefs, fmt.Sprintf("path: %s", op.PathSchema))
+			paramsArgs = append(paramsArgs, "path")
+			usedQueryTypes[op.PathSchema] = true
+		}
 		if op.QuerySchema != "" {
-			paramsDef = fmt.Sprintf("params: %s, ", op.QuerySchema)
-			paramsArg = "params"
+			paramsDefs = append(paramsDefs, fmt.Sprintf("params: %s", op.QuerySchema))
+			paramsArgs = append(paramsArgs, "params")
 			usedQueryTypes[op.QuerySchema] = true
 		}
 
+		paramsDef := ""
+		if len(paramsDefs) > 0 {
+			paramsDef = strings.Join(paramsDefs, ", ") + ", "
+		}
+		paramsArg := strings.Join(paramsArgs, ", ")
+
 		// Create query function
 		function := fmt.Sprintf(`
 export function %s<TData = Awaited<ReturnType<typeof APEX.%s>>, TError = Error>(%sopts: Omit<UseQueryOptions<Awaited<ReturnType<typeof APEX.%s>>, TError, TData, %s>, 'queryKey' | 'queryFn'> = {}) {
@@ -176,7 +234,7 @@ export function %s(%s) {
 export type %s = ReturnType<typeof %s>;`, funcName, op.Name, paramsDef, op.Name, queryKeyType, queryKeyFunc, paramsArg, op.Name, paramsArg, queryKeyFunc, paramsDef, op.Name,
 			func() string {
 				if paramsArg != "" {
-					return ", params"
+					return ", " + paramsArg
 				}
 				return ""
 			}(),
@@ -219,6 +277,9 @@ func generateTSImports(apex *ApexData) string {
 
 	// Collect all used schemas
 	for _, op := range apex.Operations {
+		if op.PathSchema != "" {
+			usedSchemas[op.PathSchema] = true
+		}
 		if op.QuerySchema != "" {
 			usedSchemas[op.QuerySchema] = true
 		}
@@ -290,22 +351,23 @@ func generateTSAPIClient(apex *ApexData, projectDir, subDir, port string) error
 		method := tsMethod(op.Method)
 		responseType := op.ResponseSchema
 
-		// Determine parameter type
-		var paramsDef, paramsArg string
+		// Determine parameter type; path parameters go into the URL
+		paramsDefs := []string{}
+		if op.PathSchema != "" {
+			paramsDefs = append(paramsDefs, fmt.Sprintf("path: %s", op.PathSchema))
+		}
+		var paramsArg string
 		if op.QuerySchema != "" {
-			paramsDef = fmt.Sprintf("params: %s", op.QuerySchema)
+			paramsDefs = append(paramsDefs, fmt.Sprintf("params: %s", op.QuerySchema))
 			paramsArg = "params"
 		} else if op.BodySchema != "" {
-			paramsDef = fmt.Sprintf("body: %s", op.BodySchema)
+			paramsDefs = append(paramsDefs, fmt.Sprintf("body: %s", op.BodySchema))
 			paramsArg = "body"
-		} else {
-			paramsDef = ""
-			paramsArg = ""
 		}
 
 		// Generate API client function
-		ts += fmt.Sprintf("async function %s(%s): Promise<%s> {\n", funcName, paramsDef, responseType)
-		ts += fmt.Sprintf("  return %s<%s>('%s'%s);\n", method, responseType, endpoint,
+		ts += fmt.Sprintf("async function %s(%s): Promise<%s> {\n", funcName, strings.Join(paramsDefs, ", "), responseType)
+		ts += fmt.Sprintf("  return %s<%s>(%s%s);\n", method, responseType, tsPathLiteral(endpoint),
 			func() string {
 				if paramsArg != "" {
 					return ", " + paramsArg
@@ -382,7 +444,8 @@ func capitalize(s string) string {
 func generateGoStructs(apex *ApexData, projectDir, subDir string) error {
 	apiDir := filepath.Join(projectDir, subDir, fmt.Sprintf("%s-star/genesis", subDir), "api")
 
-	goCode := "package api\n\n"
+	goCode := ""
+	imports := make(map[string]bool)
 
 	// Convert schemas to a map for quick lookup
 	allSchemas := make(map[string]Schema)
@@ -391,50 +454,88 @@ func generateGoStructs(apex *ApexData, projectDir, subDir string) error {
 	}
 
 	for _, schema := range apex.Schemas {
-		goCode += fmt.Sprintf("type %s struct {\n", schema.Name)
-		var fields map[string]json.RawMessage
-		json.Unmarshal(schema.Fields, &fields)
+		switch schema.Type {
+		case "Enum":
+			if enum, err := parseApexEnum(schema); err == nil {
+				goCode += enum.goEnum()
+				imports["encoding/json"], imports["fmt"] = true, true
+			}
+			continue
+		case "Union":
+			goCode += goUnion(schema)
+			imports["encoding/json"], imports["fmt"] = true, true
+			continue
+		}
+
+		fields, _ := parseSchemaFields(schema)
 
-		for key, value := range fields {
-			goCode += fmt.Sprintf("  %s %s `json:\"%s\"`\n", capitalize(key), goType(value, allSchemas), key)
+		goCode += fmt.Sprintf("type %s struct {\n", schema.Name)
+		for _, field := range fields {
+			goCode += fmt.Sprintf("  %s %s %s\n", capitalize(field.Name), field.goType(allSchemas), field.goTag())
+			if primitive := apexPrimitives[field.elementType()]; strings.Contains(primitive.goType, ".") {
+				imports[primitive.goImport] = true // time.Time and json.RawMessage
+			}
 		}
 		goCode += "}\n\n"
+
+		if defaults := goDefaultsUnmarshal(schema.Name, fields, allSchemas); defaults != "" {
+			goCode += defaults
+			imports["encoding/json"] = true
+		}
 	}
+
+	goCode = "package api\n\n" + goImportBlock(imports) + goCode
 	ensureDir(apiDir)
+	if err := os.WriteFile(filepath.Join(apiDir, "formats.go"), []byte(goFormats), 0644); err != nil {
+		return err
+	}
 	filePath := filepath.Join(apiDir, "types.go")
 	return os.WriteFile(filePath, []byte(goCode), 0644)
 }
 
-func goType(value json.RawMessage, allSchemas map[string]Schema) string {
-	// Try to unmarshal into a string (primitive type or reference)
-	var strType string
-	if err := json.Unmarshal(value, &strType); err == nil {
-		switch strType {
-		case "string":
-			return "string"
-		case "number":
-			return "float64"
-		case "boolean":
-			return "bool"
-		default:
-			// Assume it's a reference to another schema
-			if _, exists := allSchemas[strType]; exists {
-				return strType
-			}
-			return "interface{}" // Fallback for unknown types
-		}
+// goImportBlock is the import declaration of a set of packages, sorted, or "" for none
+func goImportBlock(imports map[string]bool) string {
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, fmt.Sprintf("%q", path))
+	}
+	sort.Strings(paths)
+	switch len(paths) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("import %s\n\n", paths[0])
+	default:
+		return fmt.Sprintf("import (\n  %s\n)\n\n", strings.Join(paths, "\n  "))
 	}
+}
 
-	// Try to unmarshal into an array type
-	var arrayType struct {
-		Type      string `json:"type"`
-		ArrayType string `json:"arrayType"`
+// goDefaultsUnmarshal generates an UnmarshalJSON that fills in the defaults of fields missing
+// from the JSON, or "" when no field has a default
+func goDefaultsUnmarshal(schemaName string, fields []apexField, allSchemas map[string]Schema) string {
+	presets := ""
+	for _, field := range fields {
+		value := field.goDefault(allSchemas)
+		if value == "" {
+			continue
+		}
+		if field.goPointer() {
+			presets += fmt.Sprintf("  %sDefault := %s(%s)\n", field.Name, strings.TrimPrefix(field.goType(allSchemas), "*"), value)
+			presets += fmt.Sprintf("  value.%s = &%sDefault\n", capitalize(field.Name), field.Name)
+		} else {
+			presets += fmt.Sprintf("  value.%s = %s\n", capitalize(field.Name), value)
+		}
 	}
-	if err := json.Unmarshal(value, &arrayType); err == nil && arrayType.Type == "array" {
-		return "[]" + goType(json.RawMessage(`"`+arrayType.ArrayType+`"`), allSchemas)
+	if presets == "" {
+		return ""
 	}
 
-	return "interface{}" // Fallback if type is unknown
+	code := fmt.Sprintf("// UnmarshalJSON fills in the defaults of fields missing from the JSON\nfunc (s *%s) UnmarshalJSON(data []byte) error {\n", schemaName)
+	code += fmt.Sprintf("  type plain %s\n  value := plain{}\n", schemaName)
+	code += presets
+	code += "  if err := json.Unmarshal(data, &value); err != nil {\n    return err\n  }\n"
+	code += fmt.Sprintf("  *s = %s(value)\n  return nil\n}\n\n", schemaName)
+	return code
 }
 
 func goMethod(method string) string {
@@ -501,7 +602,8 @@ func generateGoRoutes(apex *ApexData, projectDir, subDir string) error {
 	}
 
 	// Generate grouped route functions
-	goCode := "package routes\n\nimport (\n  \"github.com/go-chi/chi/v5\"\n  \"solar-system/genesis/handler\"\n)\n\n"
+	modulePath := serverModulePath(filepath.Join(projectDir, subDir, subDir+"-star"))
+	goCode := fmt.Sprintf("package routes\n\nimport (\n  \"github.com/go-chi/chi/v5\"\n  \"%s/genesis/handler\"\n)\n\n", modulePath)
 
 	routeFuncNames := []string{}
 
@@ -553,8 +655,14 @@ func generateGoHandlers(apex *ApexData, projectDir, subDir string) error {
 
 	fmt.Println("Generating Go handlers in:", handlerDir)
 
+	allSchemas := make(map[string]Schema)
+	for _, schema := range apex.Schemas {
+		allSchemas[schema.Name] = schema
+	}
+
 	// Organize handlers into groups based on API namespace
 	handlerGroups := make(map[string][]string)
+	groupImports := make(map[string]map[string]bool)
 
 	for _, op := range apex.Operations {
 		// Extract API namespace for grouping (e.g., "v1" from "/api/v1/users")
@@ -587,21 +695,43 @@ func (h *Handler) %s(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		if groupImports[groupName] == nil {
+			groupImports[groupName] = map[string]bool{"net/http": true}
+		}
+
+		// Path Parameters Handling
+		if op.PathSchema != "" {
+			handlerFunc += fmt.Sprintf("\n  pathParams := api.%s{}\n", op.PathSchema)
+			groupImports[groupName]["github.com/go-chi/chi/v5"] = true
+
+			var pathSchema Schema
+			for _, schema := range apex.Schemas {
+				if schema.Name == op.PathSchema {
+					pathSchema = schema
+				}
+			}
+			for _, param := range pathParams(op.Endpoint) {
+				field, _ := schemaField(pathSchema, param)
+				field.Name = param
+				handlerFunc += goPathParam(field, allSchemas, groupImports[groupName])
+			}
+		}
+
 		// Query Parameters Handling
 		if op.QuerySchema != "" {
 			handlerFunc += fmt.Sprintf("\n  params := api.%s{}\n  query := r.URL.Query()\n", op.QuerySchema)
 			for _, schema := range apex.Schemas {
 				if schema.Name == op.QuerySchema {
-					var fields map[string]string
-					json.Unmarshal(schema.Fields, &fields)
-					for field := range fields {
-						handlerFunc += fmt.Sprintf("  params.%s = query.Get(\"%s\")\n", capitalize(field), field)
+					fields, _ := parseSchemaFields(schema)
+					for _, field := range fields {
+						handlerFunc += goQueryParam(field, allSchemas, groupImports[groupName])
 					}
 				}
 			}
 		}
 
 		if op.BodySchema != "" {
+			groupImports[groupName]["encoding/json"] = true
 			handlerFunc += fmt.Sprintf("\n  var body api.%s\n", op.BodySchema)
 			handlerFunc += "  if err := json.NewDecoder(r.Body).Decode(&body); err != nil {\n"
 			handlerFunc += "    http.Error(w, \"Invalid request body\", http.StatusBadRequest)\n"
@@ -626,12 +756,18 @@ func (h *Handler) %s(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Write each grouped handler to its own file
+	modulePath := serverModulePath(filepath.Join(projectDir, subDir, subDir+"-star"))
 	for groupName, handlers := range handlerGroups {
 		fileName := fmt.Sprintf("%s.go", groupName)
 		filePath := filepath.Join(handlerDir, fileName)
 
 		// Overwrite the file completely with new handlers
-		handlerCode := fmt.Sprintf("package handler\n\nimport (\n  \"net/http\"\n  \"solar-system/genesis/api\"\n)\n\n%s", strings.Join(handlers, "\n"))
+		imports := []string{}
+		for path := range groupImports[groupName] {
+			imports = append(imports, fmt.Sprintf("  %q\n", path))
+		}
+		sort.Strings(imports)
+		handlerCode := fmt.Sprintf("package handler\n\nimport (\n%s  \"%s/genesis/api\"\n)\n\n%s", strings.Join(imports, ""), modulePath, strings.Join(handlers, "\n"))
 
 		err := os.WriteFile(filePath, []byte(handlerCode), 0644)
 		if err != nil {
@@ -644,37 +780,68 @@ func (h *Handler) %s(w http.ResponseWriter, r *http.Request) {
 	return nil
 }
 
-func generateRecursiveResponse(schemaName string, schemas []Schema) (string, string) {
-	processed := make(map[string]string) // Track initialized structs
-	initCode, structVar := recursiveGoStructInit(schemaName, schemas, processed)
-	return initCode, structVar
-}
-
-func resolveGoType(value json.RawMessage, schemas []Schema) string {
-	// Try to unmarshal into a string (primitive or custom type)
-	var typeString string
-	if err := json.Unmarshal(value, &typeString); err == nil {
-		// If it's a known primitive, return it directly
-		if isPrimitive(typeString) {
-			return typeString
+// goParamRead declares <name>Param from the raw string of a path or query parameter, parsed
+// into the field's type. Parsing or checking it answers 400 when it's invalid; checked reports
+// whether it does. imports gets the packages the parsing needs.
+func goParamRead(field apexField, raw, kind string, allSchemas map[string]Schema, imports map[string]bool) (code string, checked bool) {
+	fail := fmt.Sprintf("  http.Error(w, \"Invalid %s parameter: %s\", http.StatusBadRequest)\n  return\n}\n", kind, field.Name)
+	if primitive := apexPrimitives[field.Type]; primitive.goParse != "" {
+		if primitive.goImport != "" {
+			imports[primitive.goImport] = true
 		}
+		return fmt.Sprintf("%sParam, err := "+primitive.goParse+"\nif err != nil {\n", field.Name, raw) + fail, true
+	}
+	if enum, ok := findEnum(field.Type, allSchemas); ok && !enum.Integer {
+		return fmt.Sprintf("%sParam := api.%s(%s)\nif !%sParam.Valid() {\n", field.Name, field.Type, raw, field.Name) + fail, true
+	}
+	return fmt.Sprintf("%sParam := %s\n", field.Name, raw), false
+}
 
-		// Otherwise, assume it's a **custom struct reference**
-		return typeString
+// goPathParam reads one path parameter into pathParams, answering 400 when it doesn't parse as
+// the declared type
+func goPathParam(field apexField, allSchemas map[string]Schema, imports map[string]bool) string {
+	raw := fmt.Sprintf("chi.URLParam(r, \"%s\")", field.Name)
+	read, checked := goParamRead(field, raw, "path", allSchemas, imports)
+	if !checked {
+		return fmt.Sprintf("  pathParams.%s = %s\n", capitalize(field.Name), raw)
 	}
+	return indentLines(read+fmt.Sprintf("pathParams.%s = %sParam\n", capitalize(field.Name), field.Name), "  ")
+}
 
-	// Try to unmarshal into an array definition
-	var arrayDef struct {
-		Type      string `json:"type"`
-		ArrayType string `json:"arrayType"`
+// goQueryParam reads one query parameter into params, parsed like path parameters. Missing
+// optional parameters stay nil and missing defaulted ones get their default.
+func goQueryParam(field apexField, allSchemas map[string]Schema, imports map[string]bool) string {
+	name := capitalize(field.Name)
+	raw := fmt.Sprintf("query.Get(\"%s\")", field.Name)
+	read, checked := goParamRead(field, raw, "query", allSchemas, imports)
+
+	code := ""
+	switch {
+	case field.goDefault(allSchemas) != "" && !field.goPointer():
+		code = fmt.Sprintf("params.%s = %s\nif query.Has(\"%s\") {\n%s  params.%s = %sParam\n}\n", name, field.goDefault(allSchemas), field.Name, indentLines(read, "  "), name, field.Name)
+	case field.goPointer():
+		code = fmt.Sprintf("if query.Has(\"%s\") {\n%s  params.%s = &%sParam\n}\n", field.Name, indentLines(read, "  "), name, field.Name)
+	case checked:
+		code = read + fmt.Sprintf("params.%s = %sParam\n", name, field.Name)
+	default:
+		code = fmt.Sprintf("params.%s = %s\n", name, raw)
 	}
-	if err := json.Unmarshal(value, &arrayDef); err == nil && arrayDef.Type == "array" {
-		// Resolve the inner type and return it as an array
-		return "[]" + resolveGoType(json.RawMessage(`"`+arrayDef.ArrayType+`"`), schemas)
+	return indentLines(code, "  ")
+}
+
+// indentLines prefixes every line of code
+func indentLines(code, indent string) string {
+	if code == "" {
+		return ""
 	}
+	lines := strings.SplitAfter(strings.TrimSuffix(code, "\n"), "\n")
+	return indent + strings.Join(lines, indent) + "\n"
+}
 
-	// Fallback if nothing matches
-	return "interface{}"
+func generateRecursiveResponse(schemaName string, schemas []Schema) (string, string) {
+	processed := make(map[string]string) // Track initialized structs
+	initCode, structVar := recursiveGoStructInit(schemaName, schemas, processed)
+	return initCode, structVar
 }
 
 func recursiveGoStructInit(schemaName string, schemas []Schema, processed map[string]string) (string, string) {
@@ -694,6 +861,15 @@ func recursiveGoStructInit(schemaName string, schemas []Schema, processed map[st
 		return "", "" // Schema not found
 	}
 
+	// Enums are constants: inline their first value wherever they're used instead of a variable
+	if schema.Type == "Enum" {
+		enum, err := parseApexEnum(*schema)
+		if err != nil {
+			return "", ""
+		}
+		return "", "api." + enum.goConstName(enum.Values[0])
+	}
+
 	// Store the processed schema to prevent duplicate initialization
 	processed[schemaName] = schemaName
 
@@ -701,36 +877,72 @@ func recursiveGoStructInit(schemaName string, schemas []Schema, processed map[st
 	structVarName := decapitalize(schemaName) // Example: `VResponse` -> `vResponse`
 	initCode := ""
 
+	allSchemas := make(map[string]Schema)
+	for _, s := range schemas {
+		allSchemas[s.Name] = s
+	}
+
+	if schema.Type == "Union" {
+		// Unions start out as their first variant
+		variants := unionVariants(*schema)
+		if len(variants) == 0 {
+			return "", ""
+		}
+		nestedInit, nestedVar := recursiveGoStructInit(schema.Variants[variants[0]], schemas, processed)
+		initCode = fmt.Sprintf("%s := api.%s{%s: %q", structVarName, schemaName, capitalize(schema.Discriminator), variants[0])
+		if nestedVar != "" {
+			initCode += fmt.Sprintf(", %s: &%s", goVariantField(variants[0]), nestedVar)
+		}
+		return nestedInit + initCode + "}\n", structVarName
+	}
+
 	// Prepare struct initialization
 	initCode += fmt.Sprintf("%s := api.%s{\n", structVarName, schemaName)
 
-	// Unmarshal fields
-	var fields map[string]json.RawMessage
-	if err := json.Unmarshal(schema.Fields, &fields); err != nil {
+	// Parse fields
+	fields, err := parseSchemaFields(*schema)
+	if err != nil {
 		return "", "" // Handle parsing errors
 	}
 
 	// Store initialization for nested structs
 	nestedInits := ""
 
-	for fieldName, fieldType := range fields {
-		// Determine if it's a primitive or a custom type
-		resolvedType := resolveGoType(fieldType, schemas)
+	for _, field := range fields {
+		fieldName := capitalize(field.Name)
+		elementType := field.elementType()
 
-		if isPrimitive(resolvedType) {
+		primitive, isPrimitiveType := apexPrimitives[elementType]
+		if field.goPointer() {
+			// Optional and nullable fields start out unset
+			continue
+		} else if isPrimitiveType && primitive.goZero == "" {
+			// Zero values needing an import in the handler, like time.Time, are left out
+			continue
+		} else if (field.isArray() || field.isMap()) && isPrimitiveType {
+			initCode += fmt.Sprintf("  %s: %s{},\n", fieldName, field.goType(nil))
+		} else if isPrimitiveType {
 			// Directly assign default values for primitives
-			initCode += fmt.Sprintf("  %s: %s,\n", capitalize(fieldName), defaultGoValue(resolvedType))
-		} else if strings.HasPrefix(resolvedType, "[]") {
+			value := field.goDefault(allSchemas)
+			if value == "" {
+				value = primitive.goZero
+			}
+			initCode += fmt.Sprintf("  %s: %s,\n", fieldName, value)
+		} else if field.isMap() {
+			initCode += fmt.Sprintf("  %s: map[string]api.%s{},\n", fieldName, elementType)
+		} else if value := field.goDefault(allSchemas); value != "" && !field.isArray() {
+			// An enum with a default
+			initCode += fmt.Sprintf("  %s: %s,\n", fieldName, value)
+		} else if field.isArray() {
 			// It's an **array of custom structs**, initialize it properly
-			innerType := strings.TrimPrefix(resolvedType, "[]")
-			nestedInit, nestedVar := recursiveGoStructInit(innerType, schemas, processed)
+			nestedInit, nestedVar := recursiveGoStructInit(elementType, schemas, processed)
 			nestedInits += nestedInit
-			initCode += fmt.Sprintf("  %s: []api.%s{%s},\n", capitalize(fieldName), innerType, nestedVar)
+			initCode += fmt.Sprintf("  %s: []api.%s{%s},\n", fieldName, elementType, nestedVar)
 		} else {
 			// It's a **custom struct**, initialize it recursively
-			nestedInit, nestedVar := recursiveGoStructInit(resolvedType, schemas, processed)
+			nestedInit, nestedVar := recursiveGoStructInit(elementType, schemas, processed)
 			nestedInits += nestedInit
-			initCode += fmt.Sprintf("  %s: %s,\n", capitalize(fieldName), nestedVar)
+			initCode += fmt.Sprintf("  %s: %s,\n", fieldName, nestedVar)
 		}
 	}
 
@@ -739,12 +951,8 @@ func recursiveGoStructInit(schemaName string, schemas []Schema, processed map[st
 }
 
 func isPrimitive(fieldType string) bool {
-	switch fieldType {
-	case "string", "number", "boolean":
-		return true
-	default:
-		return false
-	}
+	_, exists := apexPrimitives[fieldType]
+	return exists
 }
 
 func decapitalize(str string) string {
@@ -754,19 +962,6 @@ func decapitalize(str string) string {
 	return strings.ToLower(str[:1]) + str[1:]
 }
 
-func defaultGoValue(fieldType string) string {
-	switch fieldType {
-	case "string":
-		return "\"\"" // Default empty string
-	case "number":
-		return "0.0" // Default float64 value
-	case "boolean":
-		return "false" // Default boolean value
-	default:
-		return "nil" // Default for unknown types
-	}
-}
-
 func folderExists(path string) bool {
 	_, err := os.Stat(path)
 	return !os.IsNotExist(err)
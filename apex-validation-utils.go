@@ -0,0 +1,643 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Identifiers valid in both TypeScript and Go
+var apexIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var goKeywords = toSet([]string{
+	"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for",
+	"func", "go", "goto", "if", "import", "interface", "map", "package", "range", "return",
+	"select", "struct", "switch", "type", "var",
+})
+
+var tsKeywords = toSet([]string{
+	"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do",
+	"else", "enum", "export", "extends", "false", "finally", "for", "function", "if", "import",
+	"in", "instanceof", "new", "null", "return", "super", "switch", "this", "throw", "true", "try",
+	"typeof", "var", "void", "while", "with", "implements", "interface", "let", "package",
+	"private", "protected", "public", "static", "yield", "await", "async",
+})
+
+// Global TypeScript types a schema would shadow in the generated types.ts
+var tsGlobalTypes = toSet([]string{
+	"Array", "Boolean", "Date", "Error", "Function", "Map", "Number", "Object", "Omit", "Partial",
+	"Pick", "Promise", "Record", "RegExp", "Required", "Set", "String", "Symbol",
+})
+
+var apexSchemaTypes = []string{"Path", "Query", "Body", "Response", "Custom", "Enum", "Union"}
+
+// apexValidator collects diagnostics while walking an apex document
+type apexValidator struct {
+	apex    *ApexData
+	schemas map[string]Schema
+	result  ApexValidation
+}
+
+func (v *apexValidator) errorf(path, format string, args ...interface{}) {
+	v.result.Errors = append(v.result.Errors, ApexDiagnostic{Severity: "error", Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *apexValidator) warnf(path, format string, args ...interface{}) {
+	v.result.Warnings = append(v.result.Warnings, ApexDiagnostic{Severity: "warning", Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// jsonKeyPath appends an object key to a JSON path, bracketed when it isn't an identifier
+func jsonKeyPath(path, key string) string {
+	if apexIdentifierRegex.MatchString(key) {
+		return path + "." + key
+	}
+	quoted, _ := json.Marshal(key)
+	return fmt.Sprintf("%s[%s]", path, quoted)
+}
+
+// validateApex checks everything code generation relies on. Errors would make it produce
+// broken or wrong code; warnings are suspicious but generate fine.
+func validateApex(apex *ApexData) ApexValidation {
+	v := &apexValidator{
+		apex:    apex,
+		schemas: make(map[string]Schema),
+		result:  ApexValidation{Errors: []ApexDiagnostic{}, Warnings: []ApexDiagnostic{}},
+	}
+	for _, schema := range apex.Schemas {
+		if _, exists := v.schemas[schema.Name]; !exists {
+			v.schemas[schema.Name] = schema
+		}
+	}
+
+	v.validateEndpoints()
+	v.validateSchemas()
+	v.validateOperations()
+
+	v.result.Valid = len(v.result.Errors) == 0
+	return v.result
+}
+
+// validateName checks a schema or operation name, which becomes an exported Go identifier and
+// a TypeScript type or function
+func (v *apexValidator) validateName(path, kind, name string) bool {
+	switch {
+	case name == "":
+		v.errorf(path, "%s name cannot be empty", kind)
+	case !apexIdentifierRegex.MatchString(name):
+		v.errorf(path, "%s name %q must only contain letters, digits and underscores, and not start with a digit", kind, name)
+	case goKeywords[name] || tsKeywords[name]:
+		v.errorf(path, "%s name %q is a reserved keyword", kind, name)
+	case strings.ToUpper(name[:1]) != name[:1]:
+		v.errorf(path, "%s name %q must start with an uppercase letter to be exported from the generated Go code", kind, name)
+	default:
+		return true
+	}
+	return false
+}
+
+func (v *apexValidator) validateEndpoints() {
+	seen := make(map[string]int)
+	for i, endpoint := range v.apex.Endpoints {
+		path := fmt.Sprintf("$.endpoints[%d]", i)
+
+		switch {
+		case endpoint.Path == "":
+			v.errorf(path+".path", "Endpoint path cannot be empty")
+		case !strings.HasPrefix(endpoint.Path, "/"):
+			v.errorf(path+".path", "Endpoint path %q must start with /", endpoint.Path)
+		case strings.ContainsAny(endpoint.Path, " ?#"):
+			v.errorf(path+".path", "Endpoint path %q cannot contain spaces, ? or #", endpoint.Path)
+		}
+		v.validatePathParams(path+".path", endpoint.Path)
+		if first, exists := seen[endpoint.Path]; exists {
+			v.errorf(path+".path", "Endpoint %s is already defined at $.endpoints[%d]", endpoint.Path, first)
+		} else {
+			seen[endpoint.Path] = i
+		}
+
+		if len(endpoint.Methods) == 0 {
+			v.warnf(path+".methods", "Endpoint %s has no methods", endpoint.Path)
+		}
+		methods := make(map[string]bool)
+		for j, method := range endpoint.Methods {
+			methodPath := fmt.Sprintf("%s.methods[%d]", path, j)
+			if tsMethod(method) == "" {
+				v.errorf(methodPath, "Unsupported method %q. Must be one of: GET, POST, PUT, DELETE, PATCH", method)
+			} else if methods[method] {
+				v.warnf(methodPath, "Method %s is listed twice", method)
+			}
+			methods[method] = true
+		}
+		for j, method := range endpoint.Secured {
+			if !methods[method] {
+				v.warnf(fmt.Sprintf("%s.secured[%d]", path, j), "Secured method %s is not one of the endpoint's methods", method)
+			}
+		}
+	}
+}
+
+// validatePathParams checks the {param} placeholders of an endpoint path
+func (v *apexValidator) validatePathParams(path, endpoint string) {
+	if strings.ContainsAny(pathParamRegex.ReplaceAllString(endpoint, ""), "{}") {
+		v.errorf(path, "Endpoint path %q has unbalanced braces. Path parameters look like {id}", endpoint)
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, param := range pathParams(endpoint) {
+		if !apexIdentifierRegex.MatchString(param) {
+			v.errorf(path, "Path parameter {%s} must only contain letters, digits and underscores, and not start with a digit", param)
+		} else if seen[param] {
+			v.errorf(path, "Path parameter {%s} appears twice", param)
+		}
+		seen[param] = true
+	}
+}
+
+func (v *apexValidator) validateSchemas() {
+	seen := make(map[string]int)
+	for i, schema := range v.apex.Schemas {
+		path := fmt.Sprintf("$.schemas[%d]", i)
+
+		if v.validateName(path+".name", "Schema", schema.Name) {
+			if goKeywords[decapitalize(schema.Name)] {
+				// Generated handlers name their response variable after the schema
+				v.errorf(path+".name", "Schema name %q turns into the Go keyword %q in generated handlers", schema.Name, decapitalize(schema.Name))
+			} else if goFormatFuncs[schema.Name] {
+				v.errorf(path+".name", "Schema name %q is taken by a function of the generated api package", schema.Name)
+			} else if tsGlobalTypes[schema.Name] {
+				v.warnf(path+".name", "Schema name %q shadows the built-in TypeScript type", schema.Name)
+			}
+		}
+		if first, exists := seen[schema.Name]; exists {
+			v.errorf(path+".name", "Schema %s is already defined at $.schemas[%d]", schema.Name, first)
+		} else {
+			seen[schema.Name] = i
+		}
+
+		known := false
+		for _, schemaType := range apexSchemaTypes {
+			known = known || schema.Type == schemaType
+		}
+		if !known {
+			v.errorf(path+".type", "Unknown schema type %q. Must be one of: %s", schema.Type, strings.Join(apexSchemaTypes, ", "))
+		}
+
+		switch schema.Type {
+		case "Enum":
+			v.validateEnum(path, schema)
+			continue
+		case "Union":
+			v.validateUnion(path, schema)
+			continue
+		}
+		if len(schema.Values) > 0 {
+			v.warnf(path+".values", "Values are ignored because %s is not an Enum schema", schema.Name)
+		}
+		if schema.Discriminator != "" || len(schema.Variants) > 0 {
+			v.warnf(path+".variants", "Discriminator and variants are ignored because %s is not a Union schema", schema.Name)
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(schema.Fields, &fields); err != nil || fields == nil {
+			v.errorf(path+".fields", "Fields must be an object of field name → type")
+			continue
+		}
+		v.validateFields(path+".fields", schema, fields)
+
+		for j, name := range schema.Required {
+			if _, exists := fields[name]; !exists {
+				v.warnf(fmt.Sprintf("%s.required[%d]", path, j), "Required field %q is not one of the schema's fields", name)
+			}
+		}
+	}
+
+	v.validateSchemaCycles()
+	v.validateEnumConstants()
+}
+
+// validateNoFields checks an Enum or Union schema doesn't declare fields it would ignore
+func (v *apexValidator) validateNoFields(path string, schema Schema) {
+	var fields map[string]json.RawMessage
+	json.Unmarshal(schema.Fields, &fields)
+	if len(fields) > 0 {
+		v.warnf(path+".fields", "Fields are ignored because %s is an %s schema", schema.Name, schema.Type)
+	}
+}
+
+func (v *apexValidator) validateEnum(path string, schema Schema) {
+	v.validateNoFields(path, schema)
+	if len(schema.Values) == 0 {
+		v.errorf(path+".values", "Enum %s needs at least one value", schema.Name)
+		return
+	}
+
+	for i, raw := range schema.Values {
+		var text string
+		var number int64
+		if json.Unmarshal(raw, &text) != nil && json.Unmarshal(raw, &number) != nil {
+			v.errorf(fmt.Sprintf("%s.values[%d]", path, i), "Enum value %s must be a string or an integer", raw)
+			return
+		}
+	}
+
+	parsed, err := parseApexEnum(schema)
+	if err != nil {
+		v.errorf(path+".values", "Enum %s must have only string or only integer values: %v", schema.Name, err)
+		return
+	}
+
+	seen := make(map[string]int)
+	consts := make(map[string]int)
+	for i, value := range parsed.Values {
+		valuePath := fmt.Sprintf("%s.values[%d]", path, i)
+		constName := parsed.goConstName(value)
+		switch {
+		case value == "":
+			v.errorf(valuePath, "Enum value cannot be empty")
+		case constName == schema.Name:
+			v.errorf(valuePath, "Enum value %q needs a letter or digit to name its Go constant", value)
+		}
+		if first, exists := seen[value]; exists {
+			v.errorf(valuePath, "Enum value %s is already listed at %s.values[%d]", parsed.literal(value), path, first)
+			continue
+		}
+		seen[value] = i
+		if first, exists := consts[constName]; exists {
+			v.errorf(valuePath, "Enum values %s and %s both become the Go constant %s", parsed.literal(parsed.Values[first]), parsed.literal(value), constName)
+		}
+		consts[constName] = i
+	}
+}
+
+// validateEnumConstants checks the Go constants of enums don't collide with schema names or
+// the constants of other enums, as they all share the generated api package
+func (v *apexValidator) validateEnumConstants() {
+	owners := make(map[string]string)
+	for i, schema := range v.apex.Schemas {
+		enum, err := parseApexEnum(schema)
+		if schema.Type != "Enum" || err != nil {
+			continue
+		}
+		for j, value := range enum.Values {
+			constName := enum.goConstName(value)
+			valuePath := fmt.Sprintf("$.schemas[%d].values[%d]", i, j)
+			if _, exists := v.schemas[constName]; exists {
+				v.errorf(valuePath, "Enum value %s becomes the Go constant %s, which is also a schema name", enum.literal(value), constName)
+			} else if owner, exists := owners[constName]; exists && owner != enum.Name {
+				v.errorf(valuePath, "Enum value %s becomes the Go constant %s, which enum %s defines too", enum.literal(value), constName, owner)
+			}
+			owners[constName] = enum.Name
+		}
+	}
+}
+
+// validateUnion checks a Union schema has a discriminator and object schemas as variants
+func (v *apexValidator) validateUnion(path string, schema Schema) {
+	v.validateNoFields(path, schema)
+	if !apexIdentifierRegex.MatchString(schema.Discriminator) {
+		v.errorf(path+".discriminator", "Union %s needs a discriminator: the field name that tells its variants apart", schema.Name)
+		return
+	}
+	if len(schema.Variants) == 0 {
+		v.errorf(path+".variants", "Union %s needs at least one variant", schema.Name)
+		return
+	}
+
+	goFields := map[string]string{capitalize(schema.Discriminator): schema.Discriminator}
+	for _, key := range unionVariants(schema) {
+		variantPath := jsonKeyPath(path+".variants", key)
+		name := schema.Variants[key]
+
+		goField := goVariantField(key)
+		if goField == "" {
+			v.errorf(variantPath, "Variant %q needs a letter or digit to name its Go field", key)
+		} else if other, exists := goFields[goField]; exists {
+			v.errorf(variantPath, "Variant %q and %q both become the Go field %s", key, other, goField)
+		}
+		goFields[goField] = key
+
+		variant, exists := v.schemas[name]
+		if !exists {
+			v.errorf(variantPath, "Schema %s is not defined in $.schemas", name)
+			continue
+		}
+		if variant.Type == "Enum" || variant.Type == "Union" {
+			v.errorf(variantPath, "Variant %s must be a schema with fields, not an %s", name, variant.Type)
+			continue
+		}
+
+		// The union adds the discriminator itself, a variant may only pin it to its own key
+		field, exists := schemaField(variant, schema.Discriminator)
+		if !exists {
+			continue
+		}
+		var literal string
+		if !field.isLiteral() || json.Unmarshal(field.Const, &literal) != nil || literal != key {
+			v.errorf(variantPath, "Variant %s has its own %q field. Remove it or make it {\"type\": \"string\", \"const\": %q}", name, schema.Discriminator, key)
+		}
+	}
+}
+
+func (v *apexValidator) validateFields(path string, schema Schema, fields map[string]json.RawMessage) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	goFields := make(map[string]string)
+	for _, name := range names {
+		fieldPath := jsonKeyPath(path, name)
+		if !apexIdentifierRegex.MatchString(name) {
+			v.errorf(fieldPath, "Field name %q must only contain letters, digits and underscores, and not start with a digit", name)
+			continue
+		}
+
+		// Go struct fields are the capitalized JSON names
+		goName := capitalize(name)
+		if other, exists := goFields[goName]; exists {
+			v.errorf(fieldPath, "Fields %q and %q both become the Go field %s", other, name, goName)
+		}
+		goFields[goName] = name
+
+		field, ok := v.validateField(fieldPath, fields[name])
+		if !ok {
+			continue
+		}
+		if field.Optional && toSet(schema.Required)[name] {
+			v.warnf(fieldPath+".optional", "Field %q is optional but also listed in required", name)
+		}
+		if schema.Type == "Path" {
+			if !v.isParamType(field) {
+				v.errorf(fieldPath, "Path parameters must be a primitive other than json or an enum of strings, but %q is %s", name, field.tsType())
+			} else if field.Optional || field.Nullable || field.hasDefault() {
+				v.errorf(fieldPath, "Path parameter %q is always present, so it can't be optional, nullable or have a default", name)
+			}
+		}
+		if schema.Type == "Query" && !v.isParamType(field) {
+			v.errorf(fieldPath, "Generated handlers can't parse %q from the query string. Query parameters must be a primitive other than json or an enum of strings", name)
+		}
+	}
+}
+
+// validateField checks a field is a primitive, a known schema or an array or map of either, with
+// modifiers that make sense for its type
+func (v *apexValidator) validateField(path string, value json.RawMessage) (apexField, bool) {
+	field, err := parseApexField(value)
+	if err != nil {
+		v.errorf(path, "Field type must be a type name or an object like {\"type\": \"array\", \"arrayType\": ...}")
+		return field, false
+	}
+
+	var keys map[string]json.RawMessage
+	if json.Unmarshal(value, &keys) == nil {
+		names := make([]string, 0, len(keys))
+		for key := range keys {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+		for _, key := range names {
+			if !apexFieldKeys[key] {
+				v.warnf(jsonKeyPath(path, key), "Unknown field option %q is ignored", key)
+			}
+		}
+		if field.ArrayType != "" && !field.isArray() {
+			v.warnf(path+".arrayType", "arrayType is ignored because the type isn't array")
+		}
+		if field.ValueType != "" && !field.isMap() {
+			v.warnf(path+".valueType", "valueType is ignored because the type isn't map")
+		}
+	}
+
+	switch {
+	case field.isArray():
+		if !v.validateTypeName(path+".arrayType", field.ArrayType) {
+			return field, false
+		}
+	case field.isMap():
+		if !v.validateTypeName(path+".valueType", field.ValueType) {
+			return field, false
+		}
+	default:
+		if !v.validateTypeName(path, field.Type) {
+			return field, false
+		}
+	}
+
+	_, isEnum := findEnum(field.Type, v.schemas)
+	hasLiterals := apexPrimitives[field.Type].literal != "" // Types with values JSON can spell out
+	switch {
+	case field.isLiteral() && len(field.Default) > 0:
+		v.errorf(path+".default", "A field with a const can't have a default too")
+	case field.isLiteral() && !hasLiterals:
+		v.errorf(path+".const", "Only primitive fields other than date-time and json can have a const")
+	case field.isLiteral() && field.goDefault(v.schemas) == "":
+		v.errorf(path+".const", "Const %s is not a valid %s", field.Const, field.Type)
+	case string(field.Default) == "null" && !field.Nullable:
+		v.errorf(path+".default", "A null default needs \"nullable\": true")
+	case !field.hasDefault():
+	case !hasLiterals && !isEnum:
+		v.errorf(path+".default", "Defaults are only supported for enums and primitive fields other than date-time and json")
+	case field.goDefault(v.schemas) == "" && isEnum:
+		v.errorf(path+".default", "Default %s is not a value of %s", field.Default, field.Type)
+	case field.goDefault(v.schemas) == "":
+		v.errorf(path+".default", "Default %s is not a valid %s", field.Default, field.Type)
+	}
+	return field, true
+}
+
+// validateTypeName checks a type is a primitive or a known schema
+func (v *apexValidator) validateTypeName(path, typeName string) bool {
+	if isPrimitive(typeName) {
+		return true
+	}
+	if _, exists := v.schemas[typeName]; exists {
+		return true
+	}
+	if typeName == "" {
+		v.errorf(path, "Field type cannot be empty")
+	} else {
+		v.errorf(path, "Unknown type %q. Must be one of %s, array, map or a schema name", typeName, primitiveNames())
+	}
+	return false
+}
+
+// isParamType reports whether generated handlers can parse a field from a path or query
+// parameter
+func (v *apexValidator) isParamType(field apexField) bool {
+	if enum, ok := findEnum(field.Type, v.schemas); ok {
+		return !enum.Integer
+	}
+	return isPrimitive(field.Type) && field.Type != "json"
+}
+
+// validateSchemaCycles finds schemas that contain themselves without an array or pointer in
+// between, which Go can't represent
+func (v *apexValidator) validateSchemaCycles() {
+	references := make(map[string][]string)
+	for name, schema := range v.schemas {
+		fields, _ := parseSchemaFields(schema)
+		for _, field := range fields {
+			if _, exists := v.schemas[field.Type]; exists && !field.goPointer() {
+				references[name] = append(references[name], field.Type)
+			}
+		}
+		sort.Strings(references[name])
+	}
+
+	reported := make(map[string]bool)
+	for i, schema := range v.apex.Schemas {
+		if reported[schema.Name] {
+			continue
+		}
+		if cycle := findSchemaCycle(schema.Name, references, []string{}); cycle != nil {
+			for _, name := range cycle {
+				reported[name] = true
+			}
+			v.errorf(fmt.Sprintf("$.schemas[%d].fields", i), "Schema %s contains itself: %s. Make a field optional, nullable or an array to break the cycle", schema.Name, strings.Join(cycle, " → "))
+		}
+	}
+}
+
+func findSchemaCycle(name string, references map[string][]string, stack []string) []string {
+	if len(stack) > 0 && stack[0] == name {
+		return append(stack, name)
+	}
+	for _, visited := range stack {
+		if visited == name {
+			return nil // A cycle that doesn't go through the starting schema
+		}
+	}
+	for _, next := range references[name] {
+		if cycle := findSchemaCycle(next, references, append(stack, name)); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+func (v *apexValidator) validateOperations() {
+	endpoints := make(map[string]Endpoint)
+	for _, endpoint := range v.apex.Endpoints {
+		endpoints[endpoint.Path] = endpoint
+	}
+
+	seenNames := make(map[string]int)
+	seenRoutes := make(map[string]int)
+	for i, op := range v.apex.Operations {
+		path := fmt.Sprintf("$.operations[%d]", i)
+
+		v.validateName(path+".name", "Operation", op.Name)
+		if first, exists := seenNames[op.Name]; exists {
+			v.errorf(path+".name", "Operation %s is already defined at $.operations[%d]", op.Name, first)
+		} else {
+			seenNames[op.Name] = i
+		}
+
+		methodOK := tsMethod(op.Method) != ""
+		if !methodOK {
+			v.errorf(path+".method", "Unsupported method %q. Must be one of: GET, POST, PUT, DELETE, PATCH", op.Method)
+		}
+
+		endpoint, endpointOK := endpoints[op.Endpoint]
+		if op.Endpoint == "" {
+			v.errorf(path+".endpoint", "Operation %s has no endpoint", op.Name)
+		} else if !endpointOK {
+			v.errorf(path+".endpoint", "Endpoint %s is not defined in $.endpoints", op.Endpoint)
+		} else if methodOK {
+			listed := false
+			for _, method := range endpoint.Methods {
+				listed = listed || method == op.Method
+			}
+			if !listed {
+				v.warnf(path+".method", "Endpoint %s doesn't list %s among its methods", op.Endpoint, op.Method)
+			}
+		}
+
+		// The router can't register the same method and path twice
+		route := op.Method + " " + op.Endpoint
+		if first, exists := seenRoutes[route]; exists && endpointOK && methodOK {
+			v.errorf(path, "%s is already handled by $.operations[%d]", route, first)
+		} else if !exists {
+			seenRoutes[route] = i
+		}
+
+		v.validateOperationPath(path, op)
+		v.validateOperationSchema(path+".querySchema", op.QuerySchema, "Query")
+		v.validateOperationSchema(path+".bodySchema", op.BodySchema, "Body")
+		if op.ResponseSchema == "" {
+			v.errorf(path+".responseSchema", "Operation %s needs a response schema", op.Name)
+		} else {
+			v.validateOperationSchema(path+".responseSchema", op.ResponseSchema, "Response")
+		}
+
+		if op.Method == "GET" && op.BodySchema != "" {
+			v.errorf(path+".bodySchema", "GET requests cannot have a body schema")
+		}
+		if op.QuerySchema != "" && op.Method != "GET" && op.Method != "PATCH" {
+			v.warnf(path+".querySchema", "%s requests usually don't take query parameters", op.Method)
+		}
+		if op.QuerySchema != "" && op.BodySchema != "" {
+			// The generated client only sends one of them
+			v.warnf(path+".bodySchema", "Operation %s has both a query and a body schema; the generated client only sends the query", op.Name)
+		}
+	}
+}
+
+func (v *apexValidator) validateOperationSchema(path, name, expectedType string) {
+	if name == "" {
+		return
+	}
+	schema, exists := v.schemas[name]
+	wholeValue := schema.Type == "Enum" || schema.Type == "Union" // Fine as a body or response
+	if !exists {
+		v.errorf(path, "Schema %s is not defined in $.schemas", name)
+	} else if wholeValue && (expectedType == "Path" || expectedType == "Query") {
+		v.errorf(path, "%s schemas need fields, but %s is an %s", expectedType, name, schema.Type)
+	} else if !wholeValue && schema.Type != expectedType && schema.Type != "Custom" {
+		v.warnf(path, "Schema %s is a %s schema, not a %s schema", name, schema.Type, expectedType)
+	}
+}
+
+// validateOperationPath checks pathSchema declares exactly the parameters of the endpoint path
+func (v *apexValidator) validateOperationPath(path string, op Operation) {
+	params := pathParams(op.Endpoint)
+	if op.PathSchema == "" {
+		if len(params) > 0 {
+			v.errorf(path+".pathSchema", "Endpoint %s has path parameters, so operation %s needs a path schema", op.Endpoint, op.Name)
+		}
+		return
+	}
+	if len(params) == 0 {
+		v.errorf(path+".pathSchema", "Endpoint %s has no path parameters for %s", op.Endpoint, op.PathSchema)
+		return
+	}
+
+	v.validateOperationSchema(path+".pathSchema", op.PathSchema, "Path")
+	schema, exists := v.schemas[op.PathSchema]
+	if !exists {
+		return
+	}
+	var fields map[string]json.RawMessage
+	json.Unmarshal(schema.Fields, &fields)
+
+	inPath := toSet(params)
+	for _, param := range params {
+		if _, exists := fields[param]; !exists {
+			v.errorf(path+".pathSchema", "Schema %s has no field for the path parameter {%s}", op.PathSchema, param)
+		}
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !inPath[name] {
+			v.errorf(path+".pathSchema", "Field %q of %s is not a parameter of %s", name, op.PathSchema, op.Endpoint)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// ApexDiagnostic is one problem found in apex.json
+type ApexDiagnostic struct {
+	Severity string `json:"severity"` // "error" or "warning"
+	Path     string `json:"path"`     // JSON path of the offending value, e.g. $.operations[2].endpoint
+	Message  string `json:"message"`
+}
+
+// ApexValidation is the outcome of ValidateApex. Code is only generated when Valid is true.
+type ApexValidation struct {
+	Valid    bool             `json:"valid"`
+	Errors   []ApexDiagnostic `json:"errors"`
+	Warnings []ApexDiagnostic `json:"warnings"`
+}
+
+// ValidateApex checks apex.json for everything that would make GenerateCode produce broken
+// code: dangling endpoint and schema references, unknown field types, duplicate names and
+// routes, unsupported methods and names that collide with Go or TypeScript keywords
+func (a *App) ValidateApex(dir string) (*ApexValidation, error) {
+	if err := validateProjectDir(dir); err != nil {
+		return nil, err
+	}
+
+	apex, err := getApexData(filepath.Join(getSolarDir(a.ProjectsDir), dir))
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to load apex.json: %v", err)
+	}
+
+	result := validateApex(apex)
+	fmt.Printf("🔎 Validated apex.json of %s: %d error(s), %d warning(s)\n", dir, len(result.Errors), len(result.Warnings))
+	return &result, nil
+}
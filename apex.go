@@ -5,13 +5,37 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
-// ApexData represents the entire apex.json file structure
+// ApexData represents the entire apex.json file structure. Like project.json it is versioned
+// and migrated on load, and unknown top-level fields survive a save.
 type ApexData struct {
+	Version    int         `json:"version"`
 	Endpoints  []Endpoint  `json:"endpoints"`
 	Schemas    []Schema    `json:"schemas"`
 	Operations []Operation `json:"operations"`
+
+	extra map[string]json.RawMessage
+}
+
+func (d *ApexData) UnmarshalJSON(data []byte) error {
+	type plain ApexData
+	if err := json.Unmarshal(data, (*plain)(d)); err != nil {
+		return err
+	}
+	extra, err := splitUnknownFields(data, plain{})
+	d.extra = extra
+	return err
+}
+
+func (d ApexData) MarshalJSON() ([]byte, error) {
+	type plain ApexData
+	data, err := json.Marshal(plain(d))
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknownFields(data, d.extra)
 }
 
 // Endpoint represents an API route with methods and security settings
@@ -21,12 +45,16 @@ type Endpoint struct {
 	Secured []string `json:"secured"`
 }
 
-// Schema represents an object with fields, supporting nested objects and arrays
+// Schema represents an object with fields, supporting nested objects and arrays. Enum and
+// Union schemas have no fields and use Values or Discriminator and Variants instead.
 type Schema struct {
-	Name     string          `json:"name"`
-	Type     string          `json:"type"`
-	Fields   json.RawMessage `json:"fields"` // Raw JSON to support nested structures
-	Required []string        `json:"required,omitempty"`
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	Fields        json.RawMessage   `json:"fields"` // Raw JSON to support nested structures
+	Required      []string          `json:"required,omitempty"`
+	Values        []json.RawMessage `json:"values,omitempty"`        // Enum: all strings or all integers
+	Discriminator string            `json:"discriminator,omitempty"` // Union: the field that tells variants apart
+	Variants      map[string]string `json:"variants,omitempty"`      // Union: discriminator value → schema
 }
 
 // Operation links an endpoint with schemas for queries, bodies, and responses
@@ -34,6 +62,7 @@ type Operation struct {
 	Name           string `json:"name"`
 	Endpoint       string `json:"endpoint"`
 	Method         string `json:"method"`
+	PathSchema     string `json:"pathSchema,omitempty"` // Required when the endpoint has {params}
 	QuerySchema    string `json:"querySchema,omitempty"`
 	BodySchema     string `json:"bodySchema,omitempty"`
 	ResponseSchema string `json:"responseSchema,omitempty"`
@@ -49,34 +78,88 @@ func (a *App) GetApex(dir string) (*ApexData, error) {
 	return data, nil
 }
 
-func (a *App) SaveApex(dir string, apexData ApexData) error {
-	// Define the file path
+// ApexDocument is apex.json along with the revision to pass back to SaveApexDocument
+type ApexDocument struct {
+	Apex     *ApexData `json:"apex"`
+	Revision string    `json:"revision"`
+}
+
+func (a *App) GetApexDocument(dir string) (*ApexDocument, error) {
 	apexFilePath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "apex.json")
+	unlock := lockFile(apexFilePath)
+	defer unlock()
 
-	// Marshal ApexData into formatted JSON
-	jsonData, err := json.MarshalIndent(apexData, "", "  ")
+	apexData, revision, err := loadApexUnlocked(apexFilePath)
 	if err != nil {
-		return fmt.Errorf("failed to marshal apex.json: %w", err)
+		return nil, err
+	}
+	return &ApexDocument{Apex: apexData, Revision: revision}, nil
+}
+
+// SaveApexDocument saves apex.json unless it changed since revision was loaded, and returns the
+// new revision
+func (a *App) SaveApexDocument(dir string, apexData ApexData, revision string) (string, error) {
+	apexFilePath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "apex.json")
+	unlock := lockFile(apexFilePath)
+	defer unlock()
+
+	if err := checkRevision(apexFilePath, revision); err != nil {
+		return "", err
 	}
 
-	// Write JSON to the file
-	if err := os.WriteFile(apexFilePath, jsonData, 0644); err != nil {
-		return fmt.Errorf("failed to write apex.json: %w", err)
+	// The editor only sends the fields it knows; keep the rest of what's on disk
+	if existing, _, err := loadApexUnlocked(apexFilePath); err == nil && apexData.extra == nil {
+		apexData.extra = existing.extra
+	}
+	apexData.Version = len(apexMigrations)
+
+	// Keep what's being replaced in the history too, in case it was edited outside Genesis
+	projectPath := filepath.Dir(apexFilePath)
+	if previous, err := os.ReadFile(apexFilePath); err == nil {
+		if err := recordApexRevision(projectPath, previous); err != nil {
+			fmt.Println("⚠️ Failed to record apex.json history:", err)
+		}
+	}
+
+	jsonData, err := marshalJSON(apexData)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal apex.json: %w", err)
+	}
+	if err := writeFileAtomic(apexFilePath, jsonData, 0644); err != nil {
+		return "", fmt.Errorf("failed to write apex.json: %w", err)
+	}
+	if err := recordApexRevision(projectPath, jsonData); err != nil {
+		fmt.Println("⚠️ Failed to record apex.json history:", err)
 	}
 
 	fmt.Println("✅ Successfully saved apex.json at:", apexFilePath)
-	return nil
+	return fileRevision(jsonData), nil
+}
+
+// SaveApex saves apex.json whatever its revision
+func (a *App) SaveApex(dir string, apexData ApexData) error {
+	_, err := a.SaveApexDocument(dir, apexData, "")
+	return err
 }
 
 func (a *App) GenerateCode(dir string) error {
 	a.mu.Lock()
 	apex, err := a.GetApex(dir)
+	projectDir := getSolarDir(a.ProjectsDir)
+	a.mu.Unlock()
 	if err != nil {
 		return err
 	}
 
-	projectDir := getSolarDir(a.ProjectsDir)
-	a.mu.Unlock()
+	// Broken references would silently turn into interface{} or code that doesn't compile
+	if result := validateApex(apex); !result.Valid {
+		problems := make([]string, 0, len(result.Errors))
+		for _, diagnostic := range result.Errors {
+			problems = append(problems, fmt.Sprintf("%s: %s", diagnostic.Path, diagnostic.Message))
+		}
+		return fmt.Errorf("❌ apex.json has %d error(s), fix them before generating code:\n%s", len(result.Errors), strings.Join(problems, "\n"))
+	}
+
 	port := a.GetPort(dir)
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -92,5 +175,11 @@ func (a *App) GenerateCode(dir string) error {
 	generateGoRoutes(apex, projectDir, dir)
 	generateGoHandlers(apex, projectDir, dir)
 
+	// Postgres has enum types; the other databases keep enums as plain columns
+	projectData, _ := readProjectJSON(filepath.Join(projectDir, dir, "project.json"))
+	if projectData.Database == "" || projectData.Database == "postgres" {
+		generateSQLEnums(apex, projectDir, dir)
+	}
+
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
+	_ "modernc.org/sqlite"
 )
 
 type App struct {
@@ -19,12 +20,28 @@ type App struct {
 	mu          sync.Mutex
 	ProjectsDir string
 	db          *sql.DB
+	dialect     sqlDialect // Dialect of the connected database
 	env         map[string]string
+
+	procMu    sync.Mutex
+	processes map[string]*managedProcess
+	logs      map[string]*logBuffer
+	archives  map[string]*logArchive
+	health    map[string]*serverHealth
+	watchers  map[string]*serverWatcher
+	compose   *composeRuntime
+
+	projectWatcher *projectWatcher
 }
 
 func NewApp() *App {
 	app := &App{
-		env: make(map[string]string),
+		env:       make(map[string]string),
+		processes: make(map[string]*managedProcess),
+		logs:      make(map[string]*logBuffer),
+		archives:  make(map[string]*logArchive),
+		health:    make(map[string]*serverHealth),
+		watchers:  make(map[string]*serverWatcher),
 	}
 
 	if err := app.LoadEnv(); err != nil {
@@ -38,6 +55,7 @@ func NewApp() *App {
 
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.startProjectWatcher()
 }
 
 func (a *App) OpenBrowser(url string) {
@@ -144,6 +162,7 @@ func (a *App) PickGenesisPath() string {
 	}
 
 	a.ProjectsDir = path
+	a.startProjectWatcher()
 
 	log.Println("✅ GENESIS_PATH updated to:", path)
 	return path
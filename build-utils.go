@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+)
+
+// Matches compiler output like "./handler/v1.go:12:5: undefined: foo"
+var buildDiagnosticRegex = regexp.MustCompile(`^(.+\.go):(\d+)(?::(\d+))?: (.+)$`)
+
+// buildServerBinary compiles the Go server in projectPath into output
+func buildServerBinary(projectPath, output string, extraArgs ...string) BuildResult {
+	started := time.Now()
+
+	args := append([]string{"build", "-o", output}, extraArgs...)
+	args = append(args, ".")
+
+	fmt.Println("🔨 Building server:", projectPath, "→", output)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = projectPath
+
+	var outBuffer bytes.Buffer
+	cmd.Stdout = &outBuffer
+	cmd.Stderr = &outBuffer
+
+	err := cmd.Run()
+	result := BuildResult{
+		Success:     err == nil,
+		Output:      outBuffer.String(),
+		Diagnostics: parseBuildDiagnostics(outBuffer.String(), projectPath),
+		Duration:    time.Since(started).Milliseconds(),
+	}
+
+	if err != nil {
+		fmt.Println("❌ Build failed with", len(result.Diagnostics), "diagnostics:", err)
+		return result
+	}
+
+	result.BinaryPath = output
+	fmt.Println("✅ Build succeeded in", time.Since(started).Round(time.Millisecond))
+	return result
+}
+
+// parseBuildDiagnostics extracts file/line/column/message entries from `go build` output.
+// File paths are made relative to projectPath.
+func parseBuildDiagnostics(output, projectPath string) []BuildDiagnostic {
+	diagnostics := []BuildDiagnostic{}
+
+	for _, line := range strings.Split(output, "\n") {
+		matches := buildDiagnosticRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		file := matches[1]
+		if filepath.IsAbs(file) {
+			if rel, err := filepath.Rel(projectPath, file); err == nil {
+				file = rel
+			}
+		}
+
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+
+		diagnostics = append(diagnostics, BuildDiagnostic{
+			File:    filepath.ToSlash(filepath.Clean(file)),
+			Line:    lineNumber,
+			Column:  column,
+			Message: matches[4],
+		})
+	}
+
+	return diagnostics
+}
+
+// summarizeDiagnostics turns a failed build into a short error message
+func summarizeDiagnostics(result BuildResult) string {
+	if len(result.Diagnostics) == 0 {
+		return strings.TrimSpace(result.Output)
+	}
+
+	first := result.Diagnostics[0]
+	summary := fmt.Sprintf("%s:%d:%d: %s", first.File, first.Line, first.Column, first.Message)
+	if len(result.Diagnostics) > 1 {
+		summary += fmt.Sprintf(" (and %d more)", len(result.Diagnostics)-1)
+	}
+	return summary
+}
+
+// getBuildDir returns where server binaries of a project are written
+func getBuildDir(projectRoot string) string {
+	return filepath.Join(getGenesisDir(projectRoot), "build")
+}
+
+func serverBinaryName(version string) string {
+	name := "server-" + version
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// gitShortCommit returns the abbreviated HEAD commit of the project, or "dev" without one
+func gitShortCommit(projectRoot string) string {
+	repo, err := git.PlainOpen(projectRoot)
+	if err != nil {
+		return "dev"
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "dev"
+	}
+	return head.Hash().String()[:7]
+}
+
+// pruneBuilds keeps only the newest `keep` server binaries in buildDir
+func pruneBuilds(buildDir string, keep int) {
+	entries, err := os.ReadDir(buildDir)
+	if err != nil {
+		return
+	}
+
+	var binaries []os.DirEntry
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), "server-") {
+			binaries = append(binaries, entry)
+		}
+	}
+
+	if len(binaries) <= keep {
+		return
+	}
+
+	sort.Slice(binaries, func(i, j int) bool {
+		infoI, errI := binaries[i].Info()
+		infoJ, errJ := binaries[j].Info()
+		if errI != nil || errJ != nil {
+			return binaries[i].Name() < binaries[j].Name()
+		}
+		return infoI.ModTime().Before(infoJ.ModTime())
+	})
+
+	for _, entry := range binaries[:len(binaries)-keep] {
+		os.Remove(filepath.Join(buildDir, entry.Name()))
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// BuildDiagnostic is a single compiler error reported by `go build`
+type BuildDiagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// BuildResult is the outcome of compiling a solar system's server
+type BuildResult struct {
+	Success     bool              `json:"success"`
+	BinaryPath  string            `json:"binaryPath"`
+	Version     string            `json:"version"`
+	Commit      string            `json:"commit"`
+	BuildTime   string            `json:"buildTime"`
+	Output      string            `json:"output"`
+	Diagnostics []BuildDiagnostic `json:"diagnostics"`
+	Duration    int64             `json:"duration"` // Milliseconds
+}
+
+// BuildOptions tweaks how BuildServer compiles the server
+type BuildOptions struct {
+	Version string   `json:"version"` // Defaults to <timestamp>-<commit>
+	Tidy    bool     `json:"tidy"`    // Run `go mod tidy` before building
+	Race    bool     `json:"race"`    // Build with the race detector
+	Tags    []string `json:"tags"`    // Extra build tags
+}
+
+// BuildServer compiles the solar system's server into a versioned binary under .genesis/build.
+// The binary gets main.version, main.commit and main.buildTime set through ldflags.
+func (a *App) BuildServer(dir string, opts BuildOptions) (BuildResult, error) {
+	projectRoot := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	projectPath := filepath.Join(projectRoot, dir+"-star")
+
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return BuildResult{}, fmt.Errorf("❌ Project directory does not exist: %s", projectPath)
+	}
+
+	if opts.Tidy {
+		if err := setupGoModule(projectPath); err != nil {
+			return BuildResult{}, fmt.Errorf("❌ Failed to setup Go module: %v", err)
+		}
+	}
+
+	commit := gitShortCommit(projectRoot)
+	buildTime := time.Now().UTC()
+	version := opts.Version
+	if version == "" {
+		version = buildTime.Format("20060102-150405") + "-" + commit
+	}
+
+	buildDir := getBuildDir(projectRoot)
+	if err := ensureDir(buildDir); err != nil {
+		return BuildResult{}, fmt.Errorf("❌ Failed to create build directory: %v", err)
+	}
+
+	ldflags := fmt.Sprintf("-X main.version=%s -X main.commit=%s -X main.buildTime=%s",
+		version, commit, buildTime.Format(time.RFC3339))
+	args := []string{"-ldflags", ldflags}
+	if opts.Race {
+		args = append(args, "-race")
+	}
+	if len(opts.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(opts.Tags, ","))
+	}
+
+	result := buildServerBinary(projectPath, filepath.Join(buildDir, serverBinaryName(version)), args...)
+	result.Version = version
+	result.Commit = commit
+	result.BuildTime = buildTime.Format(time.RFC3339)
+
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "server:build:"+dir, result)
+	}
+
+	if result.Success {
+		pruneBuilds(buildDir, 5)
+	}
+
+	return result, nil
+}
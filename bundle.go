@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Bump when the bundle layout changes in a way older versions can't read
+const bundleFormat = 1
+
+// BundleManifest is bundle.json at the root of a .genesis bundle
+type BundleManifest struct {
+	Format           int      `json:"format"`
+	Dir              string   `json:"dir"` // Directory of the exported project
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	Database         string   `json:"database"`
+	CreatedAt        string   `json:"createdAt"`
+	DatabaseSnapshot bool     `json:"databaseSnapshot"` // database.sql is included
+	StrippedSecrets  []string `json:"strippedSecrets"`  // .env keys whose values were left out
+}
+
+// ExportProjectOptions controls what goes into a bundle besides the project files
+type ExportProjectOptions struct {
+	IncludeDatabase bool `json:"includeDatabase"` // Dump the running database into database.sql
+	IncludeSecrets  bool `json:"includeSecrets"`  // Keep secret .env values instead of .env.example defaults
+}
+
+// ImportBundleOptions controls where a bundle is unpacked
+type ImportBundleOptions struct {
+	Dir  string `json:"dir"` // Name under solar-systems; defaults to the exported directory
+	Name string `json:"name"`
+}
+
+// ExportProject writes a project to a single .genesis bundle: project.json, apex.json,
+// sql-editor.json, the server (migrations and seeds included) and planets without
+// node_modules, git history or .genesis. path may be a directory, in which case the bundle
+// is named <dir>.genesis. Secret .env values are replaced by their .env.example defaults
+// unless IncludeSecrets is set.
+func (a *App) ExportProject(dir, path string, o ExportProjectOptions) (BundleManifest, error) {
+	if len(dir) == 0 {
+		return BundleManifest{}, fmt.Errorf("❌ Project directory cannot be empty")
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return BundleManifest{}, fmt.Errorf("❌ Project directory does not exist: %s", projectPath)
+	}
+	if len(path) == 0 {
+		return BundleManifest{}, fmt.Errorf("❌ Bundle path cannot be empty")
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, dir+".genesis")
+	} else if filepath.Ext(path) != ".genesis" {
+		path += ".genesis"
+	}
+
+	projectData, err := readProjectJSON(filepath.Join(projectPath, "project.json"))
+	if err != nil {
+		return BundleManifest{}, fmt.Errorf("❌ Failed to read project.json: %v", err)
+	}
+
+	manifest := BundleManifest{
+		Format:          bundleFormat,
+		Dir:             dir,
+		Name:            projectData.Name,
+		Description:     projectData.Description,
+		Database:        projectData.Database,
+		CreatedAt:       time.Now().Format(time.RFC3339),
+		StrippedSecrets: []string{},
+	}
+
+	var snapshot []byte
+	if o.IncludeDatabase {
+		fmt.Println("🗄️ Dumping database of project", dir)
+		snapshot, err = a.dumpDatabase(dir, projectData.Database)
+		if err != nil {
+			return BundleManifest{}, err
+		}
+		manifest.DatabaseSnapshot = snapshot != nil
+	}
+
+	fmt.Println("📦 Exporting project", dir, "to", path)
+	if err := writeBundle(path, projectPath, &manifest, snapshot, o.IncludeSecrets); err != nil {
+		os.Remove(path)
+		return BundleManifest{}, fmt.Errorf("❌ Failed to export project: %v", err)
+	}
+
+	if len(manifest.StrippedSecrets) > 0 {
+		fmt.Println("🔒 Left out secret values:", strings.Join(manifest.StrippedSecrets, ", "))
+	}
+	fmt.Println("✅ Project exported:", path)
+	return manifest, nil
+}
+
+// InspectBundle reads the manifest of a bundle without unpacking it
+func (a *App) InspectBundle(path string) (BundleManifest, error) {
+	return readBundleManifest(path)
+}
+
+// ImportBundle unpacks a .genesis bundle as a new solar system, renaming its server and module
+// when imported under another directory. The project gets its own port block and a fresh git
+// repository. A database snapshot is kept in .genesis/snapshot.sql for RestoreDatabaseSnapshot.
+func (a *App) ImportBundle(path string, o ImportBundleOptions) (string, error) {
+	a.mu.Lock()
+
+	manifest, err := readBundleManifest(path)
+	if err != nil {
+		a.mu.Unlock()
+		return "", err
+	}
+
+	dir := o.Dir
+	if dir == "" {
+		dir = manifest.Dir
+	}
+	if err := validateProjectDir(dir); err != nil {
+		a.mu.Unlock()
+		return "", err
+	}
+	if err := ensureDir(getSolarDir(a.ProjectsDir)); err != nil {
+		a.mu.Unlock()
+		return "", fmt.Errorf("❌ Error ensuring projects directory: %v", err)
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	if err := checkIfProjectExists(projectPath); err != nil {
+		a.mu.Unlock()
+		return "", err
+	}
+
+	fmt.Println("📦 Importing bundle", path, "as", dir)
+	if err := unpackBundle(path, projectPath); err != nil {
+		os.RemoveAll(projectPath)
+		a.mu.Unlock()
+		return "", fmt.Errorf("❌ Failed to import bundle: %v", err)
+	}
+
+	if dir != manifest.Dir {
+		if err := renameProjectServer(projectPath, manifest.Dir, dir); err != nil {
+			a.mu.Unlock()
+			return "", err
+		}
+	}
+
+	projectFilePath := filepath.Join(projectPath, "project.json")
+	unlock := lockFile(projectFilePath)
+	if projectData, err := readProjectJSONUnlocked(projectFilePath); err == nil && o.Name != "" {
+		projectData.Name = o.Name
+		writeProjectJSONUnlocked(projectFilePath, projectData)
+	}
+	unlock()
+
+	// Bundles leave .env out when the exported project had none; start from the example
+	serverPath := filepath.Join(projectPath, dir+"-star")
+	if !fileExists(filepath.Join(serverPath, ".env")) && fileExists(filepath.Join(serverPath, ".env.example")) {
+		if err := copyFile(filepath.Join(serverPath, ".env.example"), filepath.Join(serverPath, ".env")); err != nil {
+			fmt.Println("⚠️ Failed to create .env from .env.example:", err)
+		}
+	}
+
+	if err := a.InitGitRepo(dir); err != nil {
+		fmt.Println("⚠️ Failed to initialize git repository:", err)
+	}
+	a.mu.Unlock()
+
+	if fileExists(filepath.Join(serverPath, ".env")) {
+		if _, err := a.AssignProjectPorts(dir); err != nil {
+			fmt.Println("⚠️ Failed to assign ports:", err)
+		}
+	}
+
+	if err := a.GitCommit(dir, fmt.Sprintf("Import bundle of %s", manifest.Dir)); err != nil {
+		fmt.Println("⚠️ Failed to commit imported bundle:", err)
+	}
+
+	if len(manifest.StrippedSecrets) > 0 {
+		fmt.Println("🔒 Fill in the secret values left out of the bundle:", strings.Join(manifest.StrippedSecrets, ", "))
+	}
+	fmt.Println("✅ Bundle imported:", path, "→", projectPath)
+	return dir, nil
+}
+
+// RestoreDatabaseSnapshot loads .genesis/snapshot.sql, saved by ImportBundle, into the
+// project's running database
+func (a *App) RestoreDatabaseSnapshot(dir string) error {
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	snapshotPath := filepath.Join(projectPath, ".genesis", "snapshot.sql")
+	if !fileExists(snapshotPath) {
+		return fmt.Errorf("❌ No database snapshot found for project %s", dir)
+	}
+
+	projectData, err := readProjectJSON(filepath.Join(projectPath, "project.json"))
+	if err != nil {
+		return fmt.Errorf("❌ Failed to read project.json: %v", err)
+	}
+
+	fmt.Println("🗄️ Restoring database snapshot of project", dir)
+	if err := a.loadDatabase(dir, projectData.Database, snapshotPath); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Database snapshot restored for project", dir)
+	return nil
+}
@@ -19,7 +19,7 @@ func isDevServerRunning(planetPath string) bool {
 
 	lines := strings.Split(string(output), "\n")
 	for _, line := range lines {
-		if strings.Contains(line, planetPath) {
+		if mentionsPath(line, planetPath) {
 			return true
 		}
 	}
@@ -1,95 +1,60 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 )
 
+// AddPlanetToProject adds a planet of the given type, named after the type
 func (a *App) AddPlanetToProject(dir, planetType string) error {
-	// Define valid planets and their repo URLs
-	validPlanets := map[string]string{
-		"web":     "https://github.com/brightsidedeveloper/bsd-planet-web.git",
-		"mobile":  "https://github.com/brightsidedeveloper/bsd-planet-mobile.git",
-		"desktop": "https://github.com/brightsidedeveloper/bsd-planet-desktop.git",
-	}
-
-	// Validate the planet type
-	repoURL, exists := validPlanets[planetType]
-	if !exists {
-		return fmt.Errorf("❌ Invalid planet type: %s. Must be 'web', 'mobile', or 'desktop'", planetType)
-	}
-
-	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
-	planetsPath := filepath.Join(projectPath, "planets")
-	planetDestPath := filepath.Join(planetsPath, planetType)
-
-	// Ensure the planets directory exists
-	if err := ensureDir(planetsPath); err != nil {
-		return fmt.Errorf("❌ Failed to create planets directory: %v", err)
-	}
-
-	// Check if the planet already exists
-	if _, err := os.Stat(planetDestPath); err == nil {
-		return fmt.Errorf("❌ Planet '%s' already exists in project '%s'", planetType, dir)
-	}
-
-	// Clone the planet template from GitHub
-	if err := cloneRepoAndPrepare(repoURL, planetDestPath); err != nil {
-		return fmt.Errorf("❌ Failed to clone planet template: %v", err)
-	}
-
-	fmt.Println("✅ Successfully added", planetType, "planet to project:", dir)
-	return nil
+	return a.AddPlanet(dir, planetType, "")
 }
 
 type ClientApp struct {
-	Type   string `json:"type"`   // "web", "mobile", "desktop"
+	Name   string `json:"name"`   // Directory under planets/, e.g. "web" or "web-landing"
+	Type   string `json:"type"`   // "web", "mobile", "desktop" or a type from planets.json
 	Exists bool   `json:"exists"` // true if the planet exists
 }
 
+// GetActivePlanets lists one entry per registered planet type, marked as existing when a planet
+// named after the type exists, followed by every other planet of the project
 func (a *App) GetActivePlanets(dir string) ([]ClientApp, error) {
 	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
 	planetsPath := filepath.Join(projectPath, "planets")
 
+	// ✅ Initialize the struct slice with default `false`
+	activePlanets := []ClientApp{}
+	listed := make(map[string]bool)
+	for _, planet := range a.loadPlanetRegistry() {
+		activePlanets = append(activePlanets, ClientApp{Name: planet.Name, Type: planet.Name})
+	}
+
 	// ✅ Ensure the planets directory exists
 	if _, err := os.Stat(planetsPath); os.IsNotExist(err) {
 		fmt.Println("⚠️ Planets directory does not exist in project:", dir)
-		// Return the structure with all planets as `exists: false`
-		return []ClientApp{
-			{Type: "web", Exists: false},
-			{Type: "mobile", Exists: false},
-			{Type: "desktop", Exists: false},
-		}, nil
+		return activePlanets, nil
 	}
 
-	// ✅ Read the contents of the planets directory
-	entries, err := os.ReadDir(planetsPath)
-	if err != nil {
-		return nil, fmt.Errorf("❌ Error reading planets directory: %v", err)
-	}
-
-	// ✅ Initialize the struct slice with default `false`
-	activePlanets := []ClientApp{
-		{Type: "web", Exists: false},
-		{Type: "mobile", Exists: false},
-		{Type: "desktop", Exists: false},
-	}
+	// ✅ Loop through the planet directories and mark active planets
+	for _, name := range a.listPlanetInstances(dir) {
+		planetType := ""
+		if planet, exists := a.planetInstanceType(dir, name); exists {
+			planetType = planet.Name
+		}
 
-	// ✅ Loop through the entries and mark active planets
-	for _, entry := range entries {
-		if entry.IsDir() {
-			for i, planet := range activePlanets {
-				if planet.Type == entry.Name() { // Match against "web", "mobile", "desktop"
-					activePlanets[i].Exists = true
-				}
+		for i, planet := range activePlanets {
+			if planet.Name == name {
+				activePlanets[i].Exists = true
+				listed[name] = true
 			}
 		}
+		if !listed[name] {
+			activePlanets = append(activePlanets, ClientApp{Name: name, Type: planetType, Exists: true})
+		}
 	}
 
 	fmt.Println("✅ Active planets for project", dir, ":", activePlanets)
@@ -98,25 +63,10 @@ func (a *App) GetActivePlanets(dir string) ([]ClientApp, error) {
 
 // ✅ Opens a planet directory in VS Code
 func (a *App) OpenPlanetInVSCode(dir, planetType string) error {
-	// Define valid planet types
-	validPlanets := map[string]bool{
-		"web":     true,
-		"mobile":  true,
-		"desktop": true,
-	}
-
-	// ✅ Validate input
-	if !validPlanets[planetType] {
-		return fmt.Errorf("❌ Invalid planet type: %s. Must be 'web', 'mobile', or 'desktop'", planetType)
-	}
-
-	// ✅ Construct the path to the planet directory
-	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
-	planetPath := filepath.Join(projectPath, "planets", planetType)
-
-	// ✅ Ensure the directory exists before opening
-	if _, err := os.Stat(planetPath); os.IsNotExist(err) {
-		return fmt.Errorf("❌ Planet '%s' does not exist in project '%s'", planetType, dir)
+	// ✅ Validate input and make sure the directory exists
+	_, planetPath, err := a.resolvePlanet(dir, planetType)
+	if err != nil {
+		return err
 	}
 
 	// ✅ Open the planet in VS Code
@@ -135,15 +85,9 @@ func (a *App) OpenPlanetInVSCode(dir, planetType string) error {
 }
 
 func (a *App) DeletePlanet(dir, planetType string) error {
-	// ✅ Validate planet type
-	validPlanets := map[string]bool{
-		"web":     true,
-		"mobile":  true,
-		"desktop": true,
-	}
-
-	if !validPlanets[planetType] {
-		return fmt.Errorf("❌ Invalid planet type: %s. Must be 'web', 'mobile', or 'desktop'", planetType)
+	// ✅ Validate input and make sure the directory exists
+	if !planetNameRegex.MatchString(planetType) {
+		return fmt.Errorf("❌ Invalid planet name: %s", planetType)
 	}
 
 	// ✅ Construct the path to the planet directory
@@ -160,29 +104,19 @@ func (a *App) DeletePlanet(dir, planetType string) error {
 		return fmt.Errorf("❌ Failed to delete planet: %v", err)
 	}
 
+	if err := a.setPlanetInstanceType(dir, planetType, ""); err != nil {
+		fmt.Println("⚠️ Could not remove planet from project.json:", err)
+	}
+
 	fmt.Println("✅ Deleted", planetType, "planet for project", dir)
 	return nil
 }
 
 func (a *App) RunBash(dir, planetType, cmd string) string {
-	// ✅ Validate planet type
-	validPlanets := map[string]bool{
-		"web":     true,
-		"mobile":  true,
-		"desktop": true,
-	}
-
-	if !validPlanets[planetType] {
-		return fmt.Sprintf("❌ Invalid planet type: %s. Must be 'web', 'mobile', or 'desktop'", planetType)
-	}
-
-	// ✅ Construct the path to the planet directory
-	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
-	planetPath := filepath.Join(projectPath, "planets", planetType)
-
-	// ✅ Ensure the directory exists
-	if _, err := os.Stat(planetPath); os.IsNotExist(err) {
-		return fmt.Sprintf("❌ Planet '%s' does not exist in project '%s'", planetType, dir)
+	// ✅ Validate input and make sure the directory exists
+	_, planetPath, err := a.resolvePlanet(dir, planetType)
+	if err != nil {
+		return err.Error()
 	}
 
 	// ✅ Run the command
@@ -194,100 +128,72 @@ func (a *App) RunBash(dir, planetType, cmd string) string {
 
 func (a *App) StartDevServer(dir, planetType string) (string, error) {
 
-	// ✅ Validate planet type
-	validPlanets := map[string]bool{
-		"web":     true,
-		"mobile":  true,
-		"desktop": true,
-	}
-
-	if !validPlanets[planetType] {
-		return "", fmt.Errorf("❌ Invalid planet type: %s. Must be 'web', 'mobile', or 'desktop'", planetType)
+	// ✅ Validate input and make sure the directory exists
+	planet, planetPath, err := a.resolvePlanet(dir, planetType)
+	if err != nil {
+		return "", err
 	}
-
-	// ✅ Construct the path to the planet directory
 	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
-	planetPath := filepath.Join(projectPath, "planets", planetType)
-
-	// ✅ Ensure the directory exists
-	if _, err := os.Stat(planetPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("❌ Planet '%s' does not exist in project '%s'", planetType, dir)
-	}
 
 	// ✅ Ensure no previous processes are running before starting
 	fmt.Println("🛑 Stopping any existing dev servers for", planetType, "in project", dir)
-	_, err := a.StopDevServer(dir, planetType)
+	_, err = a.StopDevServer(dir, planetType)
 	if err != nil {
 		fmt.Println("⚠️ Warning: Could not fully stop previous dev server:", err)
 	}
 
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	// ✅ Start `npm run dev`
+	// ✅ Start the planet type's dev command, pinned to the planet's port when one is assigned
 	fmt.Println("🚀 Starting dev server for", planetType, "planet in project", dir)
-	cmd := exec.Command("npm", "run", "dev")
+	args := strings.Fields(planet.DevCommand)
+	if len(args) == 0 {
+		return "", fmt.Errorf("❌ Planet type '%s' has no dev command", planet.Name)
+	}
+	envFilePath := filepath.Join(projectPath, dir+"-star", ".env")
+	if port := readEnvValue(envFilePath, planetPortKey(planetType), ""); port != "" && planet.PortFlag != "" {
+		if err := ensurePortFree(port, "the "+planetType+" dev server"); err != nil {
+			return "", err
+		}
+		args = append(args, strings.Fields(planet.PortFlag)...)
+		args = append(args, port)
+	}
+	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Dir = planetPath
 
-	// ✅ Capture output pipes
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return "", fmt.Errorf("❌ Failed to capture stdout: %v", err)
-	}
+	processID := devServerProcessID(dir, planetType)
+	since := a.lastLogSeq(processID)
 
-	stderrPipe, err := cmd.StderrPipe()
+	// ✅ Start process in background, streaming its output into the log buffer
+	a.mu.Lock()
+	proc, err := a.startManagedProcess(processID, cmd)
+	a.mu.Unlock()
 	if err != nil {
-		return "", fmt.Errorf("❌ Failed to capture stderr: %v", err)
-	}
-
-	// ✅ Start process in background
-	if err := cmd.Start(); err != nil {
 		return "", fmt.Errorf("❌ Failed to start dev server: %v", err)
 	}
 
-	// ✅ Use a WaitGroup to block for a short period before returning
-	var wg sync.WaitGroup
-	wg.Add(2) // ✅ Wait for both stdout and stderr
+	// ✅ Wait until the dev server prints its URL (output keeps streaming afterwards)
+	waitTime := 15 * time.Second
+	fmt.Println("⏳ Waiting up to", waitTime, "for the dev server URL...")
+	url, output := a.waitForLogMatch(proc, since, waitTime, planetURLMatcher(planet))
 
-	output := ""
-
-	go func() {
-		defer wg.Done()
-		scanner := bufio.NewScanner(stdoutPipe)
-		for scanner.Scan() {
-			line := scanner.Text()
-			output += line + "\n"
-			fmt.Println(line) // ✅ Stream to UI
-		}
-	}()
-
-	go func() {
-		defer wg.Done()
-		scanner := bufio.NewScanner(stderrPipe)
-		for scanner.Scan() {
-			line := scanner.Text()
-			output += line + "\n"
-			fmt.Println(line) // ✅ Stream to UI
-		}
-	}()
-
-	// ✅ Wait for a brief period before returning
-	waitTime := 2 * time.Second
-	fmt.Println("⏳ Waiting", waitTime, "for logs before returning...")
-	time.Sleep(waitTime)
-
-	url := extractLocalURL(output) // ✅ Extract local URL from logs
-	openBrowser(url)
+	if url != "" {
+		openBrowser(url)
+	}
 
 	return output, nil
 }
 
 func (a *App) StopDevServer(dir, planetType string) (string, error) {
+	if !planetNameRegex.MatchString(planetType) {
+		return "", fmt.Errorf("❌ Invalid planet name: %s", planetType)
+	}
+
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	fmt.Println("🔍 Searching for running dev servers for:", planetType, "in project", dir)
 
+	a.stopManagedProcess(devServerProcessID(dir, planetType))
+
 	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "planets", planetType)
 
 	// 🔍 Find all running processes related to this project
@@ -302,7 +208,7 @@ func (a *App) StopDevServer(dir, planetType string) (string, error) {
 
 	for _, line := range lines {
 		// Check if the process is running in the planet directory
-		if strings.Contains(line, projectPath) {
+		if mentionsPath(line, projectPath) {
 			fields := strings.Fields(line)
 			if len(fields) > 1 {
 				pid := fields[1] // Extract PID
@@ -324,17 +230,23 @@ func (a *App) StopDevServer(dir, planetType string) (string, error) {
 }
 
 type DevServerStatus struct {
-	Web     bool `json:"web"`
-	Mobile  bool `json:"mobile"`
-	Desktop bool `json:"desktop"`
+	Web     bool            `json:"web"`
+	Mobile  bool            `json:"mobile"`
+	Desktop bool            `json:"desktop"`
+	Planets map[string]bool `json:"planets"` // Every planet of the project, keyed by name
 }
 
 func (a *App) GetDevServersStatus(dir string) DevServerStatus {
 	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "planets")
 
-	return DevServerStatus{
-		Web:     isDevServerRunning(filepath.Join(projectPath, "web")),
-		Mobile:  isDevServerRunning(filepath.Join(projectPath, "mobile")),
-		Desktop: isDevServerRunning(filepath.Join(projectPath, "desktop")),
+	status := DevServerStatus{Planets: map[string]bool{}}
+	for _, name := range a.listPlanetInstances(dir) {
+		status.Planets[name] = a.isManagedProcessRunning(devServerProcessID(dir, name)) ||
+			isDevServerRunning(filepath.Join(projectPath, name))
 	}
+
+	status.Web = status.Planets["web"]
+	status.Mobile = status.Planets["mobile"]
+	status.Desktop = status.Planets["desktop"]
+	return status
 }
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// composeRuntime is a compose implementation Genesis can shell out to
+type composeRuntime struct {
+	Name    string
+	Command []string
+}
+
+// Checked in order; the first one that answers `version` wins
+var composeRuntimes = []composeRuntime{
+	{Name: "docker compose", Command: []string{"docker", "compose"}},
+	{Name: "docker-compose", Command: []string{"docker-compose"}},
+	{Name: "podman compose", Command: []string{"podman", "compose"}},
+}
+
+// containerRuntime returns the detected compose runtime, detecting it on first use. The probes
+// run outside procMu so slow runtimes don't hold up process management.
+func (a *App) containerRuntime() (*composeRuntime, error) {
+	a.procMu.Lock()
+	compose := a.compose
+	a.procMu.Unlock()
+	if compose != nil {
+		return compose, nil
+	}
+
+	for _, candidate := range composeRuntimes {
+		cmd := exec.Command(candidate.Command[0], append(candidate.Command[1:], "version")...)
+		if err := cmd.Run(); err == nil {
+			a.procMu.Lock()
+			defer a.procMu.Unlock()
+			if a.compose == nil {
+				detected := candidate
+				a.compose = &detected
+				fmt.Println("🐳 Using container runtime:", detected.Name)
+			}
+			return a.compose, nil
+		}
+	}
+
+	return nil, fmt.Errorf("❌ No container runtime found. Install Docker (with Compose v2), docker-compose or Podman")
+}
+
+// command builds a compose command for the given compose file
+func (r *composeRuntime) command(composeFile string, args ...string) *exec.Cmd {
+	fullArgs := append(append([]string{}, r.Command[1:]...), "-f", composeFile)
+	fullArgs = append(fullArgs, args...)
+	return exec.Command(r.Command[0], fullArgs...)
+}
+
+// run executes a compose command, streaming its output to the terminal
+func (r *composeRuntime) run(composeFile string, args ...string) error {
+	cmd := r.command(composeFile, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// composePsEntry is the subset of `compose ps --format json` output Genesis uses
+type composePsEntry struct {
+	Service    string `json:"Service"`
+	Image      string `json:"Image"`
+	State      string `json:"State"`
+	Health     string `json:"Health"`
+	Publishers []struct {
+		URL           string `json:"URL"`
+		TargetPort    int    `json:"TargetPort"`
+		PublishedPort int    `json:"PublishedPort"`
+		Protocol      string `json:"Protocol"`
+	} `json:"Publishers"`
+}
+
+// serviceStatuses lists every service declared in the compose file with its container state
+func (r *composeRuntime) serviceStatuses(composeFile string) ([]ServiceStatus, error) {
+	// Services without a container still need to show up as stopped
+	servicesOutput, err := r.command(composeFile, "config", "--services").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []ServiceStatus{}
+	index := make(map[string]int)
+	for _, name := range strings.Fields(string(servicesOutput)) {
+		index[name] = len(statuses)
+		statuses = append(statuses, ServiceStatus{Name: name, State: "stopped", Ports: []string{}})
+	}
+
+	entries, err := r.psEntries(composeFile)
+	if err != nil {
+		// Older docker-compose has no JSON output; fall back to running/not running
+		fmt.Println("⚠️ Falling back to plain service status:", err)
+		running, err := r.command(composeFile, "ps", "--services", "--filter", "status=running").Output()
+		if err != nil {
+			return statuses, nil
+		}
+		for _, name := range strings.Fields(string(running)) {
+			if i, exists := index[name]; exists {
+				statuses[i].State = "running"
+			}
+		}
+		return statuses, nil
+	}
+
+	for _, entry := range entries {
+		i, exists := index[entry.Service]
+		if !exists {
+			index[entry.Service] = len(statuses)
+			i = len(statuses)
+			statuses = append(statuses, ServiceStatus{Name: entry.Service, Ports: []string{}})
+		}
+
+		statuses[i].Image = entry.Image
+		statuses[i].State = entry.State
+		statuses[i].Health = entry.Health
+		for _, publisher := range entry.Publishers {
+			if publisher.PublishedPort == 0 {
+				continue
+			}
+			statuses[i].Ports = append(statuses[i].Ports,
+				fmt.Sprintf("%d->%d/%s", publisher.PublishedPort, publisher.TargetPort, publisher.Protocol))
+		}
+	}
+
+	return statuses, nil
+}
+
+// psEntries parses `compose ps --all --format json`, which is a JSON array on early
+// Compose v2 releases and one JSON object per line on later ones
+func (r *composeRuntime) psEntries(composeFile string) ([]composePsEntry, error) {
+	output, err := r.command(composeFile, "ps", "--all", "--format", "json").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimSpace(output)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	var entries []composePsEntry
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, err
+		}
+		return entries, nil
+	}
+
+	for _, line := range bytes.Split(trimmed, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var entry composePsEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// isDatabaseService guesses whether a compose service is the project's database
+func isDatabaseService(service ServiceStatus) bool {
+	name := strings.ToLower(service.Name)
+	image := strings.ToLower(service.Image)
+	for _, hint := range []string{"postgres", "mysql", "mariadb", "mongo"} {
+		if strings.Contains(image, hint) || strings.Contains(name, hint) {
+			return true
+		}
+	}
+	return name == "db" || name == "database"
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// ServiceStatus describes one service of a solar system's docker-compose.yaml
+type ServiceStatus struct {
+	Name   string   `json:"name"`
+	Image  string   `json:"image"`
+	State  string   `json:"state"`  // "running" | "exited" | "created" | "stopped" | ...
+	Health string   `json:"health"` // "healthy" | "unhealthy" | "starting" | "" when no healthcheck
+	Ports  []string `json:"ports"`  // e.g. "5432->5432/tcp"
+}
+
+// GetContainerRuntime returns the compose command Genesis uses, e.g. "docker compose"
+func (a *App) GetContainerRuntime() (string, error) {
+	compose, err := a.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+	return compose.Name, nil
+}
+
+// GetServiceStatuses returns the state, health and ports of every compose service of a project
+func (a *App) GetServiceStatuses(dir string) ([]ServiceStatus, error) {
+	compose, err := a.containerRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := compose.serviceStatuses(a.composeFile(dir))
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to get service status: %v", err)
+	}
+	return services, nil
+}
+
+// StartService starts a single compose service
+func (a *App) StartService(dir, service string) error {
+	return a.composeServiceCommand(dir, service, "start", "up", "-d", service)
+}
+
+// StopService stops a single compose service without removing it
+func (a *App) StopService(dir, service string) error {
+	return a.composeServiceCommand(dir, service, "stop", "stop", service)
+}
+
+// RestartService restarts a single compose service
+func (a *App) RestartService(dir, service string) error {
+	return a.composeServiceCommand(dir, service, "restart", "restart", service)
+}
+
+// StreamServiceLogs follows the container logs of a service into the log buffer
+// "docker:<dir>:<service>", pushed live like any other process output.
+func (a *App) StreamServiceLogs(dir, service string) (string, error) {
+	if len(service) == 0 {
+		return "", fmt.Errorf("❌ Service name cannot be empty")
+	}
+
+	compose, err := a.containerRuntime()
+	if err != nil {
+		return "", err
+	}
+
+	processID := containerProcessID(dir, service)
+	if a.isManagedProcessRunning(processID) {
+		return processID, nil
+	}
+
+	cmd := compose.command(a.composeFile(dir), "logs", "--follow", "--tail", "200", "--no-color", service)
+	if _, err := a.startManagedProcess(processID, cmd); err != nil {
+		return "", fmt.Errorf("❌ Failed to stream logs for %s: %v", service, err)
+	}
+
+	fmt.Println("📜 Streaming logs for service", service, "in project", dir)
+	return processID, nil
+}
+
+// StopServiceLogs stops following the container logs of a service
+func (a *App) StopServiceLogs(dir, service string) {
+	a.stopManagedProcess(containerProcessID(dir, service))
+}
+
+// isDBRunning reports whether the database service of a project has a running container
+func (a *App) isDBRunning(dir string) bool {
+	compose, err := a.containerRuntime()
+	if err != nil {
+		return false
+	}
+
+	services, err := compose.serviceStatuses(a.composeFile(dir))
+	if err != nil {
+		return false
+	}
+
+	for _, service := range services {
+		if isDatabaseService(service) && service.State == "running" {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) composeFile(dir string) string {
+	return filepath.Join(getSolarDir(a.ProjectsDir), dir, dir+"-star", "docker-compose.yaml")
+}
+
+func (a *App) composeServiceCommand(dir, service, action string, args ...string) error {
+	if len(service) == 0 {
+		return fmt.Errorf("❌ Service name cannot be empty")
+	}
+
+	compose, err := a.containerRuntime()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🐳 Running", action, "for service", service, "in project", dir)
+	if err := compose.run(a.composeFile(dir), args...); err != nil {
+		return fmt.Errorf("❌ Failed to %s service %s: %v", action, service, err)
+	}
+
+	fmt.Println("✅ Service", service, "done:", action)
+	return nil
+}
+
+// containerProcessID identifies the log stream of a compose service
+func containerProcessID(dir, service string) string {
+	return "docker:" + dir + ":" + service
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -9,7 +8,6 @@ import (
 	"log"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,18 +23,13 @@ func (a *App) GetDSN(dir string) (string, error) {
 		return "", fmt.Errorf("⚠️ .env file not found in %s", envFilePath)
 	}
 
-	file, err := os.Open(envFilePath)
+	env, err := parseEnvFile(envFilePath)
 	if err != nil {
 		return "", fmt.Errorf("❌ Failed to open .env file: %v", err)
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if strings.HasPrefix(line, "DSN=") {
-			return strings.TrimSpace(strings.TrimPrefix(line, "DSN=")), nil
-		}
+	if dsn, exists := env.get("DSN"); exists {
+		return dsn, nil
 	}
 
 	return "", fmt.Errorf("⚠️ DSN not found in .env file")
@@ -56,8 +49,19 @@ func (a *App) ConnectDB(dir string) error {
 		return err
 	}
 
+	// ✅ Pick the driver for the project's database
+	projectData, _ := readProjectJSON(filepath.Join(getSolarDir(a.ProjectsDir), dir, "project.json"))
+	dialect, err := findDialect(projectData.Database)
+	if err != nil {
+		return err
+	}
+	source, err := dialect.DataSource(dsn, filepath.Join(getSolarDir(a.ProjectsDir), dir, dir+"-star"))
+	if err != nil {
+		return fmt.Errorf("❌ Invalid DSN: %v", err)
+	}
+
 	// ✅ Connect to database
-	db, err := sql.Open("pgx", dsn)
+	db, err := sql.Open(dialect.DriverName(), source)
 	if err != nil {
 		return fmt.Errorf("❌ Failed to connect to database: %v", err)
 	}
@@ -69,6 +73,7 @@ func (a *App) ConnectDB(dir string) error {
 	}
 
 	a.db = db
+	a.dialect = dialect
 	log.Println("✅ Database connected successfully!")
 	return nil
 }
@@ -88,6 +93,7 @@ func (a *App) DisconnectDB() error {
 	}
 
 	a.db = nil
+	a.dialect = nil
 	log.Println("✅ Database disconnected successfully!")
 	return nil
 }
@@ -101,35 +107,11 @@ func (a *App) GetTables(dir string) ([]string, error) {
 		return []string{}, nil // ✅ Return empty array instead of nil
 	}
 
-	query := `
-		SELECT tablename 
-		FROM pg_catalog.pg_tables 
-		WHERE schemaname = 'public';
-	`
-	fmt.Println("🔍 Running query:", query)
-
-	rows, err := a.db.Query(query)
+	tables, err := a.dialect.Tables(a.db)
 	if err != nil {
 		fmt.Printf("❌ Failed to retrieve tables: %v\n", err)
 		return []string{}, nil // ✅ Return empty array on error
 	}
-	defer rows.Close()
-
-	var tables []string
-	for rows.Next() {
-		var tableName string
-		if err := rows.Scan(&tableName); err != nil {
-			fmt.Printf("❌ Failed to scan table name: %v\n", err)
-			return []string{}, nil // ✅ Return empty array on scan error
-		}
-		fmt.Println("✅ Found table:", tableName)
-		tables = append(tables, tableName)
-	}
-
-	if err := rows.Err(); err != nil {
-		fmt.Printf("❌ Error reading table names: %v\n", err)
-		return []string{}, nil // ✅ Return empty array if iteration error
-	}
 
 	if len(tables) == 0 {
 		fmt.Println("⚠️ No tables found in the database")
@@ -158,17 +140,19 @@ func (a *App) CreateTable(dir string, tableName string, columns map[string]strin
 		return fmt.Errorf("❌ At least one column is required")
 	}
 
-	// ✅ Escape table name with double quotes to handle reserved words
-	safeTableName := fmt.Sprintf("\"%s\"", tableName)
-
-	// Construct the CREATE TABLE SQL statement
-	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (", safeTableName)
-	columnDefs := []string{}
-	for colName, colType := range columns {
-		// ✅ Escape column names with double quotes to prevent conflicts
-		columnDefs = append(columnDefs, fmt.Sprintf("\"%s\" %s", colName, colType))
+	// ✅ Postgres columns can use the enums of apex.json, whose types are created first
+	if _, isPostgres := a.dialect.(postgresDialect); isPostgres {
+		if apex, err := getApexData(filepath.Join(getSolarDir(a.ProjectsDir), dir)); err == nil {
+			var enums []apexEnum
+			columns, enums = sqlEnumColumns(apex, columns)
+			if err := applySQLEnums(a.db, enums); err != nil {
+				return fmt.Errorf("❌ Failed to create enum types: %v", err)
+			}
+		}
 	}
-	query += strings.Join(columnDefs, ", ") + ");"
+
+	// ✅ Quote identifiers the database's way to handle reserved words
+	query := a.dialect.CreateTableSQL(tableName, columns)
 
 	fmt.Println("🚀 Executing query:", query)
 
@@ -193,33 +177,12 @@ func (a *App) GetTableSchema(dir string, tableName string) (map[string]string, e
 		return nil, fmt.Errorf("⚠️ No active database connection")
 	}
 
-	// ✅ Ensure table name is lowercase for PostgreSQL queries
-	tableName = strings.ToLower(tableName)
-
-	// ✅ Query PostgreSQL information schema to get column details
-	query := `
-		SELECT column_name, data_type 
-		FROM information_schema.columns 
-		WHERE table_name = $1 AND table_schema = 'public';
-	`
-
-	rows, err := a.db.Query(query, tableName)
+	// ✅ Ask the database's catalog for column details
+	columns, err := a.dialect.Columns(a.db, tableName)
 	if err != nil {
 		fmt.Printf("❌ Failed to fetch schema for table %s: %v\n", tableName, err)
 		return nil, err
 	}
-	defer rows.Close()
-
-	// ✅ Store column names and types in a map
-	columns := make(map[string]string)
-	for rows.Next() {
-		var colName, colType string
-		if err := rows.Scan(&colName, &colType); err != nil {
-			fmt.Printf("❌ Error scanning row: %v\n", err)
-			return nil, err
-		}
-		columns[colName] = colType
-	}
 
 	// ✅ Check if the table has no columns (possibly does not exist)
 	if len(columns) == 0 {
@@ -241,11 +204,8 @@ func (a *App) DropTable(dir string, tableName string) error {
 		return fmt.Errorf("⚠️ No active database connection")
 	}
 
-	// ✅ Escape table name with double quotes to handle reserved words
-	safeTableName := fmt.Sprintf(`"%s"`, tableName)
-
 	// ✅ Construct DROP TABLE SQL statement
-	query := fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE;", safeTableName)
+	query := a.dialect.DropTableSQL(tableName)
 
 	fmt.Println("🚀 Executing query:", query)
 
@@ -328,8 +288,10 @@ type SQLQueryHistory struct {
 
 // GetSQLHistory loads the sql-editor.json file and returns the stored queries
 func (a *App) GetSQLHistory(dir string) (*SQLQueryHistory, error) {
-	filePath := a.getSQLHistoryFilePath(dir)
+	return readSQLHistory(a.getSQLHistoryFilePath(dir))
+}
 
+func readSQLHistory(filePath string) (*SQLQueryHistory, error) {
 	// Check if the file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		fmt.Println("⚠️ No SQL history found, returning empty history.")
@@ -355,8 +317,12 @@ func (a *App) GetSQLHistory(dir string) (*SQLQueryHistory, error) {
 func (a *App) SaveSQLQuery(dir, query string) error {
 	filePath := a.getSQLHistoryFilePath(dir)
 
+	// ✅ Hold the file until the new history is written, so concurrent saves all land
+	unlock := lockFile(filePath)
+	defer unlock()
+
 	// ✅ Load existing history
-	history, err := a.GetSQLHistory(dir)
+	history, err := readSQLHistory(filePath)
 	if err != nil {
 		return err
 	}
@@ -370,12 +336,7 @@ func (a *App) SaveSQLQuery(dir, query string) error {
 
 	// ✅ Append to history and save
 	history.Queries = append(history.Queries, newQuery)
-	data, err := json.MarshalIndent(history, "", "  ")
-	if err != nil {
-		return fmt.Errorf("❌ Failed to serialize SQL history: %v", err)
-	}
-
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if _, err := writeJSONUnlocked(filePath, history); err != nil {
 		return fmt.Errorf("❌ Failed to write SQL history: %v", err)
 	}
 
@@ -387,8 +348,11 @@ func (a *App) SaveSQLQuery(dir, query string) error {
 func (a *App) DeleteSQLQuery(dir, queryID string) error {
 	filePath := a.getSQLHistoryFilePath(dir)
 
+	unlock := lockFile(filePath)
+	defer unlock()
+
 	// ✅ Load existing history
-	history, err := a.GetSQLHistory(dir)
+	history, err := readSQLHistory(filePath)
 	if err != nil {
 		return err
 	}
@@ -410,12 +374,7 @@ func (a *App) DeleteSQLQuery(dir, queryID string) error {
 
 	// ✅ Update and save the modified history
 	history.Queries = newQueries
-	data, err := json.MarshalIndent(history, "", "  ")
-	if err != nil {
-		return fmt.Errorf("❌ Failed to serialize updated SQL history: %v", err)
-	}
-
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if _, err := writeJSONUnlocked(filePath, history); err != nil {
 		return fmt.Errorf("❌ Failed to update SQL history: %v", err)
 	}
 
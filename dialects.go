@@ -0,0 +1,27 @@
+package main
+
+// DatabaseOption is a database a project can be created with
+type DatabaseOption struct {
+	Name      string `json:"name"` // Value for NewProjectOptions.Database
+	Label     string `json:"label"`
+	Container bool   `json:"container"` // Runs in docker-compose rather than embedded in the server
+}
+
+var databaseLabels = map[string]string{
+	"postgres": "Postgres",
+	"mysql":    "MySQL",
+	"sqlite":   "SQLite",
+}
+
+// GetDatabases lists the databases Genesis supports for new projects
+func (a *App) GetDatabases() []DatabaseOption {
+	options := []DatabaseOption{}
+	for _, name := range dialectNames() {
+		label := databaseLabels[name]
+		if label == "" {
+			label = name
+		}
+		options = append(options, DatabaseOption{Name: name, Label: label, Container: name != "sqlite"})
+	}
+	return options
+}
@@ -10,6 +10,7 @@ import {
   DeleteProject,
   DeleteSQLQuery,
   DeleteStash,
+  DiffApexRevisions,
   DiscardChanges,
   DisconnectDB,
   DropTable,
@@ -19,8 +20,11 @@ import {
   GetAllBranches,
   GetAllStashes,
   GetApex,
+  GetApexDocument,
+  GetApexHistory,
   GetCommitHistory,
   GetCurrentBranch,
+  GetDatabases,
   GetDevServersStatus,
   GetEnvVariable,
   GetGitStatus,
@@ -37,8 +41,10 @@ import {
   OpenProjectInVSCode,
   PickGenesisPath,
   RestartServer,
+  RestoreApexRevision,
   RunBash,
   SaveApex,
+  SaveApexDocument,
   SaveEnvVariable,
   SaveSQLQuery,
   StartDevServer,
@@ -48,6 +54,7 @@ import {
   StopServer,
   SwitchBranch,
   UpdatePort,
+  ValidateApex,
 } from '../wailsjs/go/main/App'
 
 export default class Go {
@@ -64,6 +71,7 @@ export default class Go {
     delete: DeleteProject,
     open: OpenProjectInVSCode,
     changeDir: PickGenesisPath,
+    databases: GetDatabases,
   }
   static server = {
     start: StartServer,
@@ -86,6 +94,12 @@ export default class Go {
   static apex = {
     get: GetApex,
     save: SaveApex,
+    getDocument: GetApexDocument,
+    saveDocument: SaveApexDocument,
+    history: GetApexHistory,
+    diff: DiffApexRevisions,
+    restore: RestoreApexRevision,
+    validate: ValidateApex,
     generate: GenerateCode,
   }
   static db = {
@@ -10,17 +10,43 @@ export const EndpointSchema = z.object({
   secured: z.array(z.union([z.literal('GET'), z.literal('POST'), z.literal('PUT'), z.literal('DELETE'), z.literal('PATCH')])),
 })
 
+// ✅ Field Modifiers (Optional, Nullable, Default Value & Const Literal)
+const FieldModifiersSchema = z.object({
+  optional: z.boolean().optional(),
+  nullable: z.boolean().optional(),
+  default: z.union([z.string(), z.number(), z.boolean(), z.null()]).optional(),
+  const: z.union([z.string(), z.number(), z.boolean()]).optional(), // The field is always this value
+})
+
+// ✅ Built-in Field Types (Everything Else Is a Schema Name)
+export const primitiveTypes = ['string', 'number', 'integer', 'boolean', 'date-time', 'date', 'uuid', 'email', 'url', 'decimal', 'json']
+
+// ✅ Scalar Field Schema (A Type Name, Optionally With Modifiers)
+const ScalarFieldSchema = z.union([
+  z.string(), // Basic Type (e.g., "string", "integer", "uuid")
+  FieldModifiersSchema.extend({ type: z.string() }), // Type with modifiers, e.g. { type: "number", default: 1 }
+])
+
 // ✅ Base Field Schema (Handles Primitive Types & Objects)
 const BaseFieldSchema = z.union([
-  z.string(), // Basic Type (e.g., "string", "number", "boolean", "array")
-  z.object({ type: z.literal('array'), arrayType: z.string() }), // Object field
+  FieldModifiersSchema.extend({ type: z.literal('array'), arrayType: z.string() }), // Object field
+  FieldModifiersSchema.extend({ type: z.literal('map'), valueType: z.string() }), // String keys, values of one type
+  ScalarFieldSchema,
 ])
 
+// ✅ Path Schema (One Primitive Field per {param} of the Endpoint)
+export const PathSchema = z.object({
+  name: z.string(),
+  type: z.literal('Path'),
+  fields: z.record(ScalarFieldSchema), // Only simple key-value pairs (no nesting)
+  required: z.array(z.string()).default([]), // Optional field
+})
+
 // ✅ Query Schema (Only Key-Value Pairs, No Nesting)
 export const QuerySchema = z.object({
   name: z.string(),
   type: z.literal('Query'),
-  fields: z.record(z.string()), // Only simple key-value pairs (no nesting)
+  fields: z.record(ScalarFieldSchema), // Only simple key-value pairs (no nesting)
   required: z.array(z.string()).default([]), // Optional field
 })
 
@@ -44,12 +70,31 @@ export const ResponseSchema = z.object({
 export const CustomSchema = z.object({
   name: z.string(),
   type: z.literal('Custom'),
-  fields: z.record(z.union([BaseFieldSchema, z.string()])), // Allows using another schema as a field
+  fields: z.record(BaseFieldSchema), // Allows using another schema as a field
   required: z.array(z.string()).default([]), // Optional field
 })
 
+// ✅ Enum Schema (Only Strings or Only Integers, No Fields)
+export const EnumSchema = z.object({
+  name: z.string(),
+  type: z.literal('Enum'),
+  fields: z.record(BaseFieldSchema).default({}), // Unused, kept so every schema has fields
+  required: z.array(z.string()).default([]),
+  values: z.array(z.union([z.string(), z.number().int()])).default([]), // apex.json validation rejects mixed values
+})
+
+// ✅ Union Schema (Discriminator Value → Variant Schema, No Fields)
+export const UnionSchema = z.object({
+  name: z.string(),
+  type: z.literal('Union'),
+  fields: z.record(BaseFieldSchema).default({}), // Unused, kept so every schema has fields
+  required: z.array(z.string()).default([]),
+  discriminator: z.string().default('type'),
+  variants: z.record(z.string()).default({}),
+})
+
 // ✅ General Schema (Combines All Types)
-export const SchemaSchema = z.discriminatedUnion('type', [QuerySchema, BodySchema, ResponseSchema, CustomSchema])
+export const SchemaSchema = z.discriminatedUnion('type', [PathSchema, QuerySchema, BodySchema, ResponseSchema, CustomSchema, EnumSchema, UnionSchema])
 
 export const MethodEnumSchema = z.enum(['GET', 'POST', 'PUT', 'DELETE', 'PATCH'])
 export type MethodEnumType = z.infer<typeof MethodEnumSchema>
@@ -60,6 +105,7 @@ export const OperationSchema = z
     name: z.string(),
     endpoint: z.string(),
     method: MethodEnumSchema, // Ensure valid HTTP methods
+    pathSchema: z.string().optional(), // Required when the endpoint has {params}
     querySchema: z.string().optional(),
     bodySchema: z.string().optional(),
     responseSchema: z.string(), // Required field
@@ -96,12 +142,30 @@ export const ApexSchema = z.object({
   operations: z.array(OperationSchema),
 })
 
+export type FieldType = z.infer<typeof BaseFieldSchema>
+
+// ✅ Normalize a field to its object form
+export function parseField(value: FieldType): {
+  type: string
+  arrayType?: string
+  valueType?: string
+  optional?: boolean
+  nullable?: boolean
+  default?: unknown
+  const?: string | number | boolean
+} {
+  return typeof value === 'string' ? { type: value } : value
+}
+
 export type EndpointType = z.infer<typeof EndpointSchema>
+export type PathSchemaType = z.infer<typeof PathSchema>
 export type QuerySchemaType = z.infer<typeof QuerySchema>
 export type BodySchemaType = z.infer<typeof BodySchema>
 export type ResponseSchemaType = z.infer<typeof ResponseSchema>
 export type CustomSchemaType = z.infer<typeof CustomSchema>
-export type SchemaTypeTypes = QuerySchemaType | BodySchemaType | ResponseSchemaType | CustomSchemaType
+export type EnumSchemaType = z.infer<typeof EnumSchema>
+export type UnionSchemaType = z.infer<typeof UnionSchema>
+export type SchemaTypeTypes = PathSchemaType | QuerySchemaType | BodySchemaType | ResponseSchemaType | CustomSchemaType | EnumSchemaType | UnionSchemaType
 export type SchemaType = z.infer<typeof SchemaSchema>
 export type OperationType = z.infer<typeof OperationSchema>
 export type ApexDataType = z.infer<typeof ApexSchema>
@@ -110,6 +174,8 @@ export type ApexDataType = z.infer<typeof ApexSchema>
 interface ApexStore {
   apex: ApexDataType
   originalApex: ApexDataType
+  // Revision of apex.json the editor was loaded from; saving fails if the file has moved on
+  revision: string
   reset: () => void
   clear: () => void
   loadApex: (dir: string) => Promise<void>
@@ -128,15 +194,16 @@ interface ApexStore {
 export const useApexStore = create<ApexStore>()((set, get) => ({
   apex: { endpoints: [], schemas: [], operations: [] },
   originalApex: { endpoints: [], schemas: [], operations: [] },
+  revision: '',
 
   reset: () => set({ apex: get().originalApex }),
-  clear: () => set({ apex: { endpoints: [], schemas: [], operations: [] } }),
+  clear: () => set({ apex: { endpoints: [], schemas: [], operations: [] }, revision: '' }),
   // Load apex.json from backend
   loadApex: async (dir) => {
     try {
-      const rawApex = await Go.apex.get(dir)
+      const { apex: rawApex, revision } = await Go.apex.getDocument(dir)
       const apex = ApexSchema.parse(rawApex)
-      set({ apex, originalApex: apex })
+      set({ apex, originalApex: apex, revision })
     } catch (error) {
       console.error('❌ Error loading APEX data:', error)
     }
@@ -203,23 +270,14 @@ export const useApexStore = create<ApexStore>()((set, get) => ({
   // Save changes to apex.json
   saveApex: async (dir) => {
     Go.apex
-      .save(dir, get().apex)
+      .saveDocument(dir, get().apex, get().revision)
       .then(() => {
         toast.success('APEX data saved successfully')
-        Go.apex
-          .get(dir)
-          .then((rawApex) => {
-            const apex = ApexSchema.parse(rawApex)
-            set({ apex, originalApex: apex })
-          })
-          .catch((error) => {
-            console.error('❌ Error loading APEX data:', error)
-            toast.error('Error loading APEX data')
-          })
+        get().loadApex(dir)
       })
       .catch((error) => {
         console.error('❌ Error saving APEX data:', error)
-        toast.error('Error saving APEX data')
+        toast.error(String(error).includes('changed since it was loaded') ? 'apex.json changed on disk. Reload before saving' : 'Error saving APEX data')
       })
   },
 }))
@@ -16,10 +16,11 @@ import {
 import { Input } from '@/components/ui/input'
 import { Label } from '@/components/ui/label'
 import { Select, SelectContent, SelectGroup, SelectItem, SelectLabel, SelectTrigger, SelectValue } from '@/components/ui/select'
-import { GetProjectsType, GetProjectsSchema, CreateProjectSchema } from '@/types/schemas'
+import { GetProjectsType, GetProjectsSchema, CreateProjectSchema, DatabaseType, GetDatabasesSchema, GetDatabasesType } from '@/types/schemas'
 import Go from '@/Go'
 import { nameToDir } from '@/lib/utils'
 import { toast } from 'sonner'
+import { EventsOn } from '../../../wailsjs/runtime/runtime'
 import { Textarea } from '@/components/ui/textarea'
 
 export const Route = createLazyFileRoute('/_layout/projects-list')({
@@ -35,6 +36,9 @@ function RouteComponent() {
 
   useEffect(refetch, [refetch])
 
+  // Projects added, removed or edited outside Genesis
+  useEffect(() => EventsOn('projects:changed', refetch), [refetch])
+
   const projectDirAndNameTuple = useMemo(() => projects?.map(({ dir, project: { name } }) => [dir, name]) ?? [], [projects])
 
   return (
@@ -69,7 +73,12 @@ function CreateProject({ refetch, projectDirAndNameTuple }: { refetch: () => voi
 
   const [name, setName] = useState('')
   const [description, setDescription] = useState('')
-  const [database, setDatabase] = useState<'postgres' | ''>('')
+  const [database, setDatabase] = useState<DatabaseType | ''>('')
+  const [databases, setDatabases] = useState<GetDatabasesType>([])
+
+  useEffect(() => {
+    Go.projects.databases().then((d) => setDatabases(GetDatabasesSchema.parse(d)))
+  }, [])
 
   const [loading, setLoading] = useState(false)
 
@@ -96,13 +105,16 @@ function CreateProject({ refetch, projectDirAndNameTuple }: { refetch: () => voi
     })
 
     setLoading(true)
-    Go.projects.create(project).then(() => {
-      refetch()
-      closeBtnRef.current?.click()
-      toast('Project Created', {
-        description: 'Your project has been created.',
+    Go.projects
+      .create(project)
+      .then(() => {
+        refetch()
+        closeBtnRef.current?.click()
+        toast('Project Created', {
+          description: 'Your project has been created.',
+        })
       })
-    })
+      .catch((error) => toast.error('Failed to create project', { description: String(error) }))
 
     setLoading(false)
   }
@@ -134,14 +146,18 @@ function CreateProject({ refetch, projectDirAndNameTuple }: { refetch: () => voi
         </div>
         <div className="grid grid-cols-4 items-center gap-4">
           <Label className="text-right">Database</Label>
-          <Select value={database} onValueChange={(db: 'postgres') => setDatabase(db)}>
+          <Select value={database} onValueChange={(db: DatabaseType) => setDatabase(db)}>
             <SelectTrigger className="col-span-3">
               <SelectValue />
             </SelectTrigger>
             <SelectContent>
               <SelectGroup>
                 <SelectLabel>Database</SelectLabel>
-                <SelectItem value="postgres">Postgres</SelectItem>
+                {databases.map(({ name, label }) => (
+                  <SelectItem key={name} value={name}>
+                    {label}
+                  </SelectItem>
+                ))}
               </SelectGroup>
             </SelectContent>
           </Select>
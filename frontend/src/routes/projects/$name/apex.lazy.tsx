@@ -16,10 +16,18 @@ import { Popover, PopoverContent, PopoverTrigger } from '@/components/ui/popover
 import { ScrollArea } from '@/components/ui/scroll-area'
 import { Select, SelectContent, SelectGroup, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select'
 import Go from '@/Go'
-import { EndpointSchema, MethodEnumType, OperationSchema, SchemaType, useApexStore } from '@/hooks/useApexStore'
+import { EndpointSchema, FieldType, MethodEnumType, OperationSchema, parseField, SchemaType, useApexStore } from '@/hooks/useApexStore'
 import useDirAndName from '@/hooks/useDirAndName'
 import { cn } from '@/lib/utils'
-import { GetServerStatusSchema } from '@/types/schemas'
+import {
+  ApexDiffSchema,
+  ApexDiffType,
+  ApexHistorySchema,
+  ApexHistoryType,
+  ApexValidationSchema,
+  ApexValidationType,
+  GetServerStatusSchema,
+} from '@/types/schemas'
 import { createLazyFileRoute } from '@tanstack/react-router'
 import isEqual from 'lodash.isequal'
 import { Check, ChevronsUpDown } from 'lucide-react'
@@ -47,6 +55,10 @@ function RouteComponent() {
 
   const [generating, setGenerating] = useState(false)
 
+  // Problems found in apex.json on the last Generate, with the JSON path of each
+  const [validation, setValidation] = useState<ApexValidationType | null>(null)
+  useEffect(() => setValidation(null), [originalApex])
+
   return (
     <div className="flex flex-col gap-4">
       <div className="flex items-center justify-between">
@@ -61,43 +73,68 @@ function RouteComponent() {
             </Button>
           </div>
         ) : (
-          <Button
-            size="sm"
-            disabled={generating}
-            onClick={() => {
-              setGenerating(true)
-              Go.apex
-                .generate(dir)
-                .then(() => {
-                  toast.success('Code generated.')
-                  Go.server
-                    .status(dir)
-                    .then((status) => GetServerStatusSchema.parse(status))
-                    .then((status) => {
-                      if (status.server === 'running') {
-                        Go.server.restartServer(dir).then(() => {
-                          toast.success('Server restarted.')
+          <div className="flex gap-4">
+            <Dialog>
+              <DialogTrigger>
+                <Button size="sm" variant="secondary">
+                  History
+                </Button>
+              </DialogTrigger>
+              <ApexHistoryDialog />
+            </Dialog>
+            <Button
+              size="sm"
+              disabled={generating}
+              onClick={() => {
+                setGenerating(true)
+                Go.apex
+                  .validate(dir)
+                  .then((v) => ApexValidationSchema.parse(v))
+                  .then((v) => {
+                    setValidation(v)
+                    if (!v.valid) throw new Error(`apex.json has ${v.errors.length} error(s)`)
+                    return Go.apex.generate(dir)
+                  })
+                  .then(() => {
+                    toast.success('Code generated.')
+                    Go.server
+                      .status(dir)
+                      .then((status) => GetServerStatusSchema.parse(status))
+                      .then((status) => {
+                        if (status.server === 'running') {
+                          Go.server.restartServer(dir).then(() => {
+                            toast.success('Server restarted.')
+                            setGenerating(false)
+                          })
+                        } else {
                           setGenerating(false)
-                        })
-                      } else {
+                        }
+                      })
+                      .catch(() => {
+                        toast.error('Failed to restart server.')
                         setGenerating(false)
-                      }
-                    })
-                    .catch(() => {
-                      toast.error('Failed to restart server.')
-                      setGenerating(false)
-                    })
-                })
-                .catch(() => {
-                  toast.error('Failed to generate code.')
-                  setGenerating(false)
-                })
-            }}
-          >
-            Generate
-          </Button>
+                      })
+                  })
+                  .catch((error) => {
+                    toast.error('Failed to generate code.', { description: String(error) })
+                    setGenerating(false)
+                  })
+              }}
+            >
+              Generate
+            </Button>
+          </div>
         )}
       </div>
+      {validation && validation.errors.length + validation.warnings.length > 0 && (
+        <div className="flex flex-col gap-1 text-sm">
+          {[...validation.errors, ...validation.warnings].map(({ severity, path, message }) => (
+            <div key={severity + path + message} className={cn(severity === 'error' ? 'text-destructive' : 'text-muted-foreground')}>
+              <code>{path}</code> {message}
+            </div>
+          ))}
+        </div>
+      )}
       <hr />
       <div className="flex relative">
         <ScrollArea className="flex-1 h-[calc(100vh-var(--header-height)-40px-64px)]">
@@ -140,7 +177,7 @@ function RouteComponent() {
 function ViewOperation({ operationName }: { operationName: string }) {
   const { apex, updateOperation } = useApexStore()
 
-  const { endpoint, method, name, responseSchema, bodySchema, querySchema } = useMemo(
+  const { endpoint, method, name, responseSchema, bodySchema, querySchema, pathSchema } = useMemo(
     () => OperationSchema.parse(apex.operations.find((o) => o.name === operationName)),
     [apex.operations, operationName]
   )
@@ -165,6 +202,15 @@ function ViewOperation({ operationName }: { operationName: string }) {
     [apex.schemas, method]
   )
 
+  // Endpoints like /users/{id} need a Path schema with one field per parameter
+  const hasPathParams = endpoint.includes('{')
+  const [path, setPath] = useState(pathSchema ?? '')
+  useEffect(() => {
+    setPath(pathSchema ?? '')
+  }, [pathSchema])
+
+  const pathOptions = useMemo(() => apex.schemas.filter((s) => s.type === 'Path').map(({ name }) => name), [apex.schemas])
+
   const [open2, setOpen2] = useState(false)
   const [response, setResponse] = useState(responseSchema)
   useEffect(() => {
@@ -174,24 +220,27 @@ function ViewOperation({ operationName }: { operationName: string }) {
   const responseSchemaOptions = useMemo(() => apex.schemas.map(({ name }) => name).filter((s) => s.endsWith('Response')), [apex.schemas])
 
   const isDirty = useMemo(
-    () => response !== responseSchema || (querySchema ?? bodySchema ?? '') !== request,
-    [bodySchema, querySchema, request, response, responseSchema]
+    () => response !== responseSchema || (querySchema ?? bodySchema ?? '') !== request || (pathSchema ?? '') !== path,
+    [bodySchema, path, pathSchema, querySchema, request, response, responseSchema]
   )
 
   const reset = useCallback(() => {
     setRequest(bodySchema ?? querySchema ?? '')
+    setPath(pathSchema ?? '')
     setResponse(responseSchema)
-  }, [bodySchema, querySchema, responseSchema])
+  }, [bodySchema, pathSchema, querySchema, responseSchema])
 
   const saveOperation = useCallback(() => {
     if (!response) return toast.error('Please select a response schema.')
+    if (hasPathParams && !path) return toast.error('Please select a path schema.')
+    const operationPath = hasPathParams ? path : undefined
     if (request) {
-      if (method === 'GET') updateOperation(operationName, { name, method, endpoint, querySchema: request, responseSchema: response })
-      else updateOperation(operationName, { name, method, endpoint, bodySchema: request, responseSchema: response })
+      if (method === 'GET') updateOperation(operationName, { name, method, endpoint, pathSchema: operationPath, querySchema: request, responseSchema: response })
+      else updateOperation(operationName, { name, method, endpoint, pathSchema: operationPath, bodySchema: request, responseSchema: response })
       return toast.success('Operation saved.')
     }
-    updateOperation(operationName, { name, method, endpoint, responseSchema: response })
-  }, [endpoint, method, name, operationName, request, response, updateOperation])
+    updateOperation(operationName, { name, method, endpoint, pathSchema: operationPath, responseSchema: response })
+  }, [endpoint, hasPathParams, method, name, operationName, path, request, response, updateOperation])
 
   return (
     <div className="pr-4">
@@ -203,6 +252,31 @@ function ViewOperation({ operationName }: { operationName: string }) {
       </p>
       <hr className="pb-4 mt-4" />
       <div className="flex flex-col gap-4">
+        {hasPathParams && (
+          <div className="flex flex-col gap-2">
+            <h4 className="text-lg font-semibold">Path</h4>
+            <Select value={path} onValueChange={setPath}>
+              <SelectTrigger>
+                <SelectValue placeholder="Select Path..." />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectGroup>
+                  {pathOptions.map((p) => (
+                    <SelectItem key={p} value={p}>
+                      {p}
+                    </SelectItem>
+                  ))}
+                </SelectGroup>
+              </SelectContent>
+            </Select>
+            {path && (
+              <div className="flex flex-col gap-2">
+                <SchemaDisplay schemaName={path} />
+                <GoSchemaDisplay schemaName={path} />
+              </div>
+            )}
+          </div>
+        )}
         <div className="flex flex-col gap-2">
           <h4 className="text-lg font-semibold">Request</h4>
           <Popover open={open} onOpenChange={setOpen}>
@@ -323,36 +397,44 @@ function collectSchemasRecursively(schema: SchemaType, allSchemas: SchemaType[],
 
   const collectedSchemas = [schema] // Start with the main schema
 
-  for (const value of Object.values(schema.fields)) {
-    let referencedSchemaName: string | null = null
-
-    if (typeof value === 'string' && allSchemas.find(({ name }) => name === value)) {
-      referencedSchemaName = value
-    } else if (typeof value === 'object' && value.type === 'array') {
-      referencedSchemaName = value.arrayType
-    }
-
-    if (referencedSchemaName) {
-      const referencedSchema = allSchemas.find(({ name }) => name === referencedSchemaName)
-      if (referencedSchema) {
-        collectedSchemas.push(...collectSchemasRecursively(referencedSchema, allSchemas, processedSchemas))
-      }
+  for (const referencedSchemaName of referencedSchemaNames(schema)) {
+    const referencedSchema = allSchemas.find(({ name }) => name === referencedSchemaName)
+    if (referencedSchema) {
+      collectedSchemas.push(...collectSchemasRecursively(referencedSchema, allSchemas, processedSchemas))
     }
   }
 
   return collectedSchemas
 }
 
+// ✅ Schemas a schema refers to, through its fields or union variants
+function referencedSchemaNames(schema: SchemaType): string[] {
+  const names = Object.values(schema.fields).map((value) => {
+    const field = parseField(value)
+    return (field.type === 'array' ? field.arrayType : field.type === 'map' ? field.valueType : field.type) ?? ''
+  })
+  return schema.type === 'Union' ? [...names, ...Object.values(schema.variants)] : names
+}
+
 // ✅ Format schema to TypeScript type declarations
 function formatSchema(schema: SchemaType, allSchemas: SchemaType[], depth = 0): string {
   if (!schema.fields) return ''
 
   const indent = '  '.repeat(depth)
+  if (schema.type === 'Enum') {
+    return `${indent}type ${schema.name} = ${schema.values.map((v) => JSON.stringify(v)).join(' | ')};\n`
+  }
+  if (schema.type === 'Union') {
+    const variants = Object.entries(schema.variants).map(([key, name]) => `${indent}  | ({ ${schema.discriminator}: ${JSON.stringify(key)} } & ${name})`)
+    return `${indent}type ${schema.name} =\n${variants.join('\n')};\n`
+  }
   let result = `${indent}type ${schema.name} = {\n`
 
   for (const [key, value] of Object.entries(schema.fields)) {
-    const fieldType = resolveType(value, allSchemas, depth + 1)
-    const isRequired = schema.required?.includes(key)
+    const field = parseField(value)
+    const baseType = field.const !== undefined ? JSON.stringify(field.const) : resolveType(value, allSchemas, depth + 1)
+    const fieldType = baseType + (field.nullable ? ' | null' : '')
+    const isRequired = schema.type === 'Path' || (schema.required?.includes(key) && !field.optional)
     result += `${indent}  ${key}${isRequired ? '' : '?'}: ${fieldType};\n`
   }
 
@@ -360,33 +442,42 @@ function formatSchema(schema: SchemaType, allSchemas: SchemaType[], depth = 0):
   return result
 }
 
+// ✅ TypeScript types of the built-in field types
+const tsPrimitives: Record<string, string> = {
+  string: 'string',
+  number: 'number',
+  integer: 'number',
+  boolean: 'boolean',
+  'date-time': 'string',
+  date: 'string',
+  uuid: 'string',
+  email: 'string',
+  url: 'string',
+  decimal: 'string',
+  json: 'unknown',
+}
+
 // ✅ Recursively resolve field types
-function resolveType(
-  value:
-    | string
-    | {
-        type: 'array'
-        arrayType: string
-      },
-  allSchemas: SchemaType[],
-  depth: number
-): string {
+function resolveType(value: FieldType, allSchemas: SchemaType[], depth: number): string {
+  const field = parseField(value)
+
   // Handle primitives
-  if (typeof value === 'string' && ['string', 'number', 'boolean'].includes(value)) {
-    return value
+  if (field.type in tsPrimitives) {
+    return tsPrimitives[field.type]
   }
 
-  // Handle arrays (including custom types)
-  if (typeof value === 'object' && value.type === 'array') {
-    return `${resolveType(value.arrayType, allSchemas, depth)}[]`
+  // Handle arrays and maps (including custom types)
+  if (field.type === 'array') {
+    return `${resolveType(field.arrayType ?? '', allSchemas, depth)}[]`
+  }
+  if (field.type === 'map') {
+    return `Record<string, ${resolveType(field.valueType ?? '', allSchemas, depth)}>`
   }
 
   // Handle custom schema references (recursively expand)
-  if (typeof value === 'string') {
-    const referencedSchema = allSchemas.find(({ name }) => name === value)
-    if (referencedSchema) {
-      return referencedSchema.name // Correctly reference schema name
-    }
+  const referencedSchema = allSchemas.find(({ name }) => name === field.type)
+  if (referencedSchema) {
+    return referencedSchema.name // Correctly reference schema name
   }
 
   return 'unknown' // Fallback for unsupported types
@@ -420,20 +511,10 @@ function collectGoSchemasRecursively(schema: SchemaType, allSchemas: SchemaType[
 
   const collectedSchemas = [schema] // Start with the main schema
 
-  for (const value of Object.values(schema.fields)) {
-    let referencedSchemaName: string | null = null
-
-    if (typeof value === 'string' && allSchemas.find(({ name }) => name === value)) {
-      referencedSchemaName = value
-    } else if (typeof value === 'object' && value.type === 'array') {
-      referencedSchemaName = value.arrayType
-    }
-
-    if (referencedSchemaName) {
-      const referencedSchema = allSchemas.find(({ name }) => name === referencedSchemaName)
-      if (referencedSchema) {
-        collectedSchemas.push(...collectGoSchemasRecursively(referencedSchema, allSchemas, processedSchemas))
-      }
+  for (const referencedSchemaName of referencedSchemaNames(schema)) {
+    const referencedSchema = allSchemas.find(({ name }) => name === referencedSchemaName)
+    if (referencedSchema) {
+      collectedSchemas.push(...collectGoSchemasRecursively(referencedSchema, allSchemas, processedSchemas))
     }
   }
 
@@ -448,12 +529,26 @@ function formatGoSchema(schema: SchemaType, allSchemas: SchemaType[], processedS
   if (processedSchemas.has(schema.name)) return ''
   processedSchemas.add(schema.name)
 
+  if (schema.type === 'Enum') {
+    const integer = schema.values.some((v) => typeof v === 'number')
+    const consts = schema.values.map((v) => `\t${schema.name}${goEnumWords(String(v))} ${schema.name} = ${JSON.stringify(v)}\n`)
+    return `type ${schema.name} ${integer ? 'int64' : 'string'}\n\nconst (\n${consts.join('')})\n\n`
+  }
+  if (schema.type === 'Union') {
+    const variants = Object.entries(schema.variants).map(([key, name]) => `\t${goEnumWords(key)} *${name} \`json:"-"\`\n`)
+    return `type ${schema.name} struct {\n\t${capitalizeGo(schema.discriminator)} string \`json:"${schema.discriminator}"\`\n${variants.join('')}}\n\n`
+  }
+
   let result = `type ${schema.name} struct {\n`
 
   for (const [key, value] of Object.entries(schema.fields)) {
-    const fieldType = resolveGoType(value, allSchemas)
+    const field = parseField(value)
+    const optional = schema.type !== 'Path' && (!schema.required?.includes(key) || !!field.optional)
+    const hasDefault = field.const !== undefined || (field.default !== undefined && field.default !== null)
+    const pointer = !['array', 'map', 'json'].includes(field.type) && (field.nullable || (optional && !hasDefault))
+    const fieldType = (pointer ? '*' : '') + resolveGoType(value, allSchemas)
 
-    result += `\t${capitalizeGo(key)} ${fieldType} \`json:"${key}"\`\n`
+    result += `\t${capitalizeGo(key)} ${fieldType} \`json:"${key}${optional && !hasDefault ? ',omitempty' : ''}"\`\n`
   }
 
   result += `}\n\n`
@@ -461,32 +556,47 @@ function formatGoSchema(schema: SchemaType, allSchemas: SchemaType[], processedS
   return result
 }
 
-// ✅ Recursively resolve Go types (primitives, arrays, custom types)
-function resolveGoType(value: string | { type: 'array'; arrayType: string }, allSchemas: SchemaType[]): string {
-  if (typeof value === 'string') {
-    switch (value) {
-      case 'string':
-        return 'string'
-      case 'number':
-        return 'float64'
-      case 'boolean':
-        return 'bool'
-      default:
-        return value // Custom type
-    }
-  }
+// ✅ Go types of the built-in field types
+const goPrimitives: Record<string, string> = {
+  string: 'string',
+  number: 'float64',
+  integer: 'int64',
+  boolean: 'bool',
+  'date-time': 'time.Time',
+  date: 'string',
+  uuid: 'string',
+  email: 'string',
+  url: 'string',
+  decimal: 'string',
+  json: 'json.RawMessage',
+}
 
-  if (typeof value === 'object' && value.type === 'array') {
-    return `[]${resolveGoType(value.arrayType, allSchemas)}`
+// ✅ Recursively resolve Go types (primitives, arrays, maps, custom types)
+function resolveGoType(value: FieldType, allSchemas: SchemaType[]): string {
+  const field = parseField(value)
+  if (field.type in goPrimitives) {
+    return goPrimitives[field.type]
+  }
+  switch (field.type) {
+    case 'array':
+      return `[]${resolveGoType(field.arrayType ?? '', allSchemas)}`
+    case 'map':
+      return `map[string]${resolveGoType(field.valueType ?? '', allSchemas)}`
+    default:
+      return allSchemas.some(({ name }) => name === field.type) ? field.type : 'interface{}' // Custom type
   }
-
-  return 'interface{}' // Fallback for unknown types
 }
 
 function capitalizeGo(str: string): string {
   return str.charAt(0).toUpperCase() + str.slice(1)
 }
 
+// ✅ Go name of an enum value or union variant, e.g. "in-progress" -> InProgress, -1 -> Minus1
+function goEnumWords(value: string): string {
+  const words = value.replace(/^-(?=\d)/, 'Minus ').match(/[A-Za-z0-9]+/g) ?? []
+  return words.map((w) => capitalizeGo(w.toLowerCase())).join('')
+}
+
 function CreateOperationDialog() {
   const { addOperation, apex } = useApexStore()
   const [name, setName] = useState('')
@@ -627,3 +737,91 @@ function CreateOperationDialog() {
     </DialogContent>
   )
 }
+
+function ApexHistoryDialog() {
+  const { dir } = useDirAndName()
+  const { loadApex } = useApexStore()
+
+  const [history, setHistory] = useState<ApexHistoryType>([])
+  useEffect(() => {
+    Go.apex
+      .history(dir)
+      .then((h) => setHistory(ApexHistorySchema.parse(h)))
+      .catch(() => toast.error('Failed to load apex.json history'))
+  }, [dir])
+
+  // What restoring the selected revision would change compared to apex.json now
+  const [selected, setSelected] = useState('')
+  const [diff, setDiff] = useState<ApexDiffType | null>(null)
+  useEffect(() => {
+    setDiff(null)
+    if (!selected) return
+    Go.apex
+      .diff(dir, '', selected)
+      .then((d) => setDiff(ApexDiffSchema.parse(d)))
+      .catch(() => toast.error('Failed to compare revisions'))
+  }, [dir, selected])
+
+  const [restoring, setRestoring] = useState(false)
+  const closeRef = useRef<HTMLButtonElement>(null)
+
+  function restore() {
+    setRestoring(true)
+    Go.apex
+      .restore(dir, selected)
+      .then(() => loadApex(dir))
+      .then(() => {
+        toast.success('apex.json restored')
+        closeRef.current?.click()
+      })
+      .catch(() => toast.error('Failed to restore apex.json'))
+      .finally(() => setRestoring(false))
+  }
+
+  return (
+    <DialogContent className="sm:max-w-[600px]">
+      <DialogHeader>
+        <DialogTitle>History</DialogTitle>
+        <DialogDescription>Restore an earlier version of apex.json. The current version stays in the history.</DialogDescription>
+      </DialogHeader>
+      <ScrollArea className="h-72">
+        <div className="flex flex-col gap-2">
+          {history.length === 0 && <p className="text-sm text-muted-foreground">No saved revisions yet</p>}
+          {history.map(({ revision, savedAt, endpoints, schemas, operations, current }) => (
+            <button
+              key={revision}
+              disabled={current}
+              onClick={() => setSelected((r) => (r === revision ? '' : revision))}
+              className={cn(revision === selected && 'bg-primary/15', 'hover:bg-primary/10 rounded-lg p-2 text-left disabled:opacity-50')}
+            >
+              <div className="font-medium">
+                {new Date(savedAt).toLocaleString()}
+                {current && ' (current)'}
+              </div>
+              <div className="text-sm text-muted-foreground">
+                {endpoints} endpoints, {schemas} schemas, {operations} operations
+              </div>
+            </button>
+          ))}
+        </div>
+      </ScrollArea>
+      {diff && (
+        <div className="text-sm">
+          {diff.changes.length === 0
+            ? 'Same as the current version'
+            : diff.changes.map(({ section, name, change }) => (
+                <div key={section + name}>
+                  {change} {section.slice(0, -1)} <code>{name}</code>
+                </div>
+              ))}
+        </div>
+      )}
+      <DialogClose className="hidden" ref={closeRef} />
+      <DialogFooter>
+        <Button onClick={restore} disabled={!selected || restoring}>
+          Restore
+        </Button>
+      </DialogFooter>
+    </DialogContent>
+  )
+}
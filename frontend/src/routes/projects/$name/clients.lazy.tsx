@@ -19,6 +19,7 @@ import { createLazyFileRoute } from '@tanstack/react-router'
 import { FileTerminal, Trash } from 'lucide-react'
 import { useCallback, useEffect, useRef, useState } from 'react'
 import { toast } from 'sonner'
+import { EventsOn } from '../../../../wailsjs/runtime/runtime'
 
 export const Route = createLazyFileRoute('/projects/$name/clients')({
   component: RouteComponent,
@@ -32,6 +33,9 @@ function RouteComponent() {
   }, [dir])
   useEffect(refetchClients, [refetchClients])
 
+  // Planets added or removed outside Genesis
+  useEffect(() => EventsOn('project:planets:' + dir, refetchClients), [dir, refetchClients])
+
   const [devRunning, setDevRunning] = useState<GetClientDevServersType>({ web: false, mobile: false, desktop: false })
   const refetchDev = useCallback(() => {
     Go.clients.devServers(dir).then((a) => setDevRunning(GetClientDevServersSchema.parse(a)))
@@ -36,6 +36,7 @@ import { toast } from 'sonner'
 import { useApexStore } from '@/hooks/useApexStore'
 import { useEffect, useState } from 'react'
 import { GetClientDevServersSchema, GetServerStatusSchema } from '@/types/schemas'
+import { EventsOn } from '../../../../wailsjs/runtime/runtime'
 
 export const Route = createLazyFileRoute('/projects/$name')({
   component: RouteComponent,
@@ -51,6 +52,20 @@ function RouteComponent() {
     loadApex(dir)
   }, [clear, loadApex, dir])
 
+  // apex.json edited outside Genesis; unsaved edits are kept until saved or reset
+  useEffect(
+    () =>
+      EventsOn('project:apex:' + dir, () => {
+        const { apex, originalApex } = useApexStore.getState()
+        if (apex === originalApex) return loadApex(dir)
+        toast('apex.json changed on disk', {
+          description: 'Reload to discard your unsaved edits',
+          action: { label: 'Reload', onClick: () => loadApex(dir) },
+        })
+      }),
+    [loadApex, dir]
+  )
+
   const [deleting, setDeleting] = useState(false)
 
   async function deleteProject() {
@@ -5,7 +5,7 @@ import { createLazyFileRoute } from '@tanstack/react-router'
 import { useState, useMemo, useRef, useEffect, useCallback } from 'react'
 import { Button } from '@/components/ui/button'
 import useDirAndName from '@/hooks/useDirAndName'
-import { SchemaSchema, SchemaType, useApexStore } from '@/hooks/useApexStore'
+import { EnumSchemaType, parseField, primitiveTypes, SchemaSchema, SchemaType, UnionSchemaType, useApexStore } from '@/hooks/useApexStore'
 import isEqual from 'lodash.isequal'
 import {
   Dialog,
@@ -38,7 +38,7 @@ function RouteComponent() {
 
   const [search, setSearch] = useState('')
 
-  const [tab, setTab] = useState<'All' | 'Query' | 'Body' | 'Response' | 'Custom'>('All')
+  const [tab, setTab] = useState<SchemaTypeUnion | 'All'>('All')
 
   const filteredSchemas = useMemo(() => {
     return apex.schemas
@@ -75,7 +75,11 @@ function RouteComponent() {
       <hr />
       <div className="flex relative">
         <ScrollArea className="flex-1 h-[calc(100vh-var(--header-height)-40px-64px)]">
-          {data ? (
+          {data && data.type === 'Enum' ? (
+            <UpdateEnum schema={data} />
+          ) : data && data.type === 'Union' ? (
+            <UpdateUnion schema={data} />
+          ) : data ? (
             <UpdateSchema schema={data} />
           ) : (
             <div className="flex flex-col items-center justify-center h-full min-h-96">
@@ -102,10 +106,13 @@ function RouteComponent() {
               <SelectContent>
                 <SelectGroup>
                   <SelectItem value="All">All</SelectItem>
+                  <SelectItem value="Path">Path</SelectItem>
                   <SelectItem value="Query">Query</SelectItem>
                   <SelectItem value="Body">Body</SelectItem>
                   <SelectItem value="Response">Response</SelectItem>
                   <SelectItem value="Custom">Custom</SelectItem>
+                  <SelectItem value="Enum">Enum</SelectItem>
+                  <SelectItem value="Union">Union</SelectItem>
                 </SelectGroup>
               </SelectContent>
             </Select>
@@ -128,12 +135,21 @@ function RouteComponent() {
 }
 
 const schemaTypeOrder: Record<string, number> = {
-  Query: 1,
-  Body: 2,
-  Response: 3,
-  Custom: 4,
+  Path: 1,
+  Query: 2,
+  Body: 3,
+  Response: 4,
+  Custom: 5,
+  Enum: 6,
+  Union: 7,
 }
 
+// Schemas other schemas can use as a field type
+const referenceableTypes = ['Custom', 'Enum', 'Union']
+
+// Types named as they are, without the type as a suffix
+const unsuffixedTypes = ['Custom', 'Enum', 'Union']
+
 function CreateSchemaDialog() {
   const { addSchema, apex } = useApexStore()
   const [type, setType] = useState<SchemaTypeUnion | ''>('')
@@ -148,19 +164,19 @@ function CreateSchemaDialog() {
         onSubmit={(e) => {
           e.preventDefault()
           const cleanName = name.replace(/[^\w]/g, '')
-          const finalName = type === 'Custom' ? cleanName : cleanName + type
+          const finalName = unsuffixedTypes.includes(type) ? cleanName : cleanName + type
           if (!finalName) return toast.error('Name is required')
           if (finalName.includes(' ')) return toast.error('Name cannot contain spaces')
           if (!type) return toast.error('Type is required')
           if (apex.schemas.some((s) => s.name === finalName)) return toast.error('Schema already exists')
-          addSchema({ name: finalName, type, fields: {}, required: [] })
+          addSchema(SchemaSchema.parse({ name: finalName, type, fields: {}, required: [] }))
           setName('')
           setType('')
           closeRef.current?.click()
         }}
       >
         <DialogHeader>
-          <DialogTitle>{!type && !name ? 'Create Schema' : name + (type === 'Custom' ? '' : type)}</DialogTitle>
+          <DialogTitle>{!type && !name ? 'Create Schema' : name + (unsuffixedTypes.includes(type) ? '' : type)}</DialogTitle>
           <DialogDescription>Create a new schema!</DialogDescription>
         </DialogHeader>
         <div className="flex flex-col gap-4">
@@ -176,10 +192,13 @@ function CreateSchemaDialog() {
               </SelectTrigger>
               <SelectContent>
                 <SelectGroup>
+                  <SelectItem value="Path">Path</SelectItem>
                   <SelectItem value="Query">Query</SelectItem>
                   <SelectItem value="Body">Body</SelectItem>
                   <SelectItem value="Response">Response</SelectItem>
                   <SelectItem value="Custom">Custom</SelectItem>
+                  <SelectItem value="Enum">Enum</SelectItem>
+                  <SelectItem value="Union">Union</SelectItem>
                 </SelectGroup>
               </SelectContent>
             </Select>
@@ -194,10 +213,10 @@ function CreateSchemaDialog() {
   )
 }
 
-export type SchemaTypeUnion = 'Query' | 'Body' | 'Response' | 'Custom'
+export type SchemaTypeUnion = 'Path' | 'Query' | 'Body' | 'Response' | 'Custom' | 'Enum' | 'Union'
 
 function UpdateSchema({ schema }: { schema: SchemaType }) {
-  const { apex, deleteSchema, updateSchema } = useApexStore()
+  const { apex, updateSchema } = useApexStore()
 
   const formatedFields = useMemo(
     () =>
@@ -267,64 +286,36 @@ function UpdateSchema({ schema }: { schema: SchemaType }) {
     setRequired(schema.required)
   }, [formatedFields, schema.required])
 
-  const closeRef = useRef<HTMLButtonElement>(null)
   return (
     <div className="flex flex-col gap-4 pr-4">
       <div className="flex items-center justify-between">
         <h3 className="text-xl font-semibold">{name}</h3>
-        <Dialog>
-          <DialogTrigger>
-            <Trash className="size-5 text-destructive" />
-          </DialogTrigger>
-          <DialogContent>
-            <DialogHeader>
-              <DialogTitle>Delete {schema.name}?</DialogTitle>
-              <DialogDescription>Are you sure you want to delete {schema.name}?</DialogDescription>
-            </DialogHeader>
-            <DialogFooter>
-              <div className="flex items-center gap-4 justify-between">
-                <DialogClose asChild>
-                  <Button ref={closeRef} variant="secondary">
-                    Cancel
-                  </Button>
-                </DialogClose>
-                <Button
-                  variant="destructive"
-                  onClick={() => {
-                    deleteSchema(name)
-                    closeRef.current?.click()
-                  }}
-                >
-                  Delete
-                </Button>
-              </div>
-            </DialogFooter>
-          </DialogContent>
-        </Dialog>
+        <DeleteSchemaDialog name={name} />
       </div>
       <hr />
       <div className="flex flex-col gap-4">
         {fields.length > 0 ? (
           fields.map(({ id, key, value }) => {
             let insert = null
-            const isArray = typeof value === 'object'
-            if (isArray) {
+            const field = parseField(value)
+            const isCollection = field.type === 'array' || field.type === 'map'
+            if (isCollection) {
+              const isArray = field.type === 'array'
+              const label = (t: string) => (isArray ? `${t}[]` : `map<${t}>`)
               insert = (
                 <>
                   <Select
-                    value={value.arrayType}
+                    value={isArray ? field.arrayType : field.valueType}
                     onValueChange={(v) => {
                       setFields((c) => {
                         const index = c.findIndex((f) => f.id === id)
                         if (index === -1) return c
                         return c.map((f, i) => {
                           if (i === index) {
+                            const base = parseField(f.value)
                             return {
                               ...f,
-                              value: {
-                                type: 'array',
-                                arrayType: v,
-                              },
+                              value: isArray ? { ...base, type: 'array' as const, arrayType: v } : { ...base, type: 'map' as const, valueType: v },
                             }
                           }
                           return f
@@ -338,18 +329,20 @@ function UpdateSchema({ schema }: { schema: SchemaType }) {
                     <SelectContent>
                       <SelectGroup>
                         <SelectLabel>Basic Types</SelectLabel>
-                        <SelectItem value="string">string[]</SelectItem>
-                        <SelectItem value="number">number[]</SelectItem>
-                        <SelectItem value="boolean">boolean[]</SelectItem>
+                        {primitiveTypes.map((t) => (
+                          <SelectItem key={t} value={t}>
+                            {label(t)}
+                          </SelectItem>
+                        ))}
                       </SelectGroup>
                       <SelectGroup>
                         <SelectLabel>Custom</SelectLabel>
                         {apex.schemas
-                          .filter(({ type }) => type === 'Custom')
+                          .filter(({ type }) => referenceableTypes.includes(type))
                           .map(({ name }) => {
                             return (
                               <SelectItem key={name} value={name}>
-                                {name}[]
+                                {label(name)}
                               </SelectItem>
                             )
                           })}
@@ -361,7 +354,7 @@ function UpdateSchema({ schema }: { schema: SchemaType }) {
             }
 
             return (
-              <div key={id} className={cn('grid grid-cols-4 items-center gap-2', isArray && 'grid-cols-5')}>
+              <div key={id} className={cn('grid grid-cols-4 items-center gap-2', isCollection && 'grid-cols-5')}>
                 <div className="flex items-center gap-1">
                   <Label>Required</Label>
                   <Checkbox
@@ -391,25 +384,32 @@ function UpdateSchema({ schema }: { schema: SchemaType }) {
                   }
                 />
                 <Select
-                  value={typeof value === 'object' ? value.type : value}
+                  value={field.type}
                   onValueChange={(v) => {
                     setFields((c) => {
                       const index = c.findIndex((f) => f.id === id)
                       if (index === -1) return c
                       return c.map((f, i) => {
                         if (i === index) {
+                          // Keep the optional and nullable modifiers, a default won't fit the new type
+                          const { arrayType, valueType, optional, nullable } = parseField(f.value)
+                          const modifiers = { ...(optional && { optional }), ...(nullable && { nullable }) }
                           if (v === 'array') {
                             return {
                               ...f,
-                              value: {
-                                type: v,
-                                arrayType: typeof f.value === 'object' ? f.value.arrayType : 'string',
-                              },
+                              value: { ...modifiers, type: 'array' as const, arrayType: arrayType ?? valueType ?? 'string' },
                             }
                           }
+                          if (v === 'map') {
+                            return {
+                              ...f,
+                              value: { ...modifiers, type: 'map' as const, valueType: valueType ?? arrayType ?? 'string' },
+                            }
+                          }
+                          const hasModifiers = optional || nullable
                           return {
                             ...f,
-                            value: v,
+                            value: hasModifiers ? { ...modifiers, type: v } : v,
                           }
                         }
                         return f
@@ -423,25 +423,34 @@ function UpdateSchema({ schema }: { schema: SchemaType }) {
                   <SelectContent>
                     <SelectGroup>
                       <SelectLabel>Basic Types</SelectLabel>
-                      <SelectItem value="string">string</SelectItem>
-                      <SelectItem value="number">number</SelectItem>
-                      <SelectItem value="boolean">boolean</SelectItem>
-                      {schema.type !== 'Query' && <SelectItem value="array">Array</SelectItem>}
+                      {primitiveTypes
+                        // Parameters are parsed from strings, raw JSON can't be
+                        .filter((t) => t !== 'json' || (schema.type !== 'Query' && schema.type !== 'Path'))
+                        .map((t) => (
+                          <SelectItem key={t} value={t}>
+                            {t}
+                          </SelectItem>
+                        ))}
+                      {schema.type !== 'Query' && schema.type !== 'Path' && <SelectItem value="array">Array</SelectItem>}
+                      {schema.type !== 'Query' && schema.type !== 'Path' && <SelectItem value="map">Map</SelectItem>}
+                    </SelectGroup>
+                    <SelectGroup>
+                      <SelectLabel>Custom</SelectLabel>
+                      {apex.schemas
+                        // Path and query parameters can only be enums of strings
+                        .filter((s) =>
+                          schema.type === 'Query' || schema.type === 'Path'
+                            ? s.type === 'Enum' && s.values.every((v) => typeof v === 'string')
+                            : referenceableTypes.includes(s.type)
+                        )
+                        .map(({ name }) => {
+                          return (
+                            <SelectItem key={name} value={name}>
+                              {name}
+                            </SelectItem>
+                          )
+                        })}
                     </SelectGroup>
-                    {schema.type !== 'Query' && (
-                      <SelectGroup>
-                        <SelectLabel>Custom</SelectLabel>
-                        {apex.schemas
-                          .filter(({ type }) => type === 'Custom')
-                          .map(({ name }) => {
-                            return (
-                              <SelectItem key={name} value={name}>
-                                {name}
-                              </SelectItem>
-                            )
-                          })}
-                      </SelectGroup>
-                    )}
                   </SelectContent>
                 </Select>
                 {insert}
@@ -479,3 +488,223 @@ function UpdateSchema({ schema }: { schema: SchemaType }) {
     </div>
   )
 }
+
+function DeleteSchemaDialog({ name }: { name: string }) {
+  const { deleteSchema } = useApexStore()
+  const closeRef = useRef<HTMLButtonElement>(null)
+
+  return (
+    <Dialog>
+      <DialogTrigger>
+        <Trash className="size-5 text-destructive" />
+      </DialogTrigger>
+      <DialogContent>
+        <DialogHeader>
+          <DialogTitle>Delete {name}?</DialogTitle>
+          <DialogDescription>Are you sure you want to delete {name}?</DialogDescription>
+        </DialogHeader>
+        <DialogFooter>
+          <div className="flex items-center gap-4 justify-between">
+            <DialogClose asChild>
+              <Button ref={closeRef} variant="secondary">
+                Cancel
+              </Button>
+            </DialogClose>
+            <Button
+              variant="destructive"
+              onClick={() => {
+                deleteSchema(name)
+                closeRef.current?.click()
+              }}
+            >
+              Delete
+            </Button>
+          </div>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  )
+}
+
+function UpdateEnum({ schema }: { schema: EnumSchemaType }) {
+  const { updateSchema } = useApexStore()
+
+  const formatedValues = useMemo(() => schema.values.map((value) => ({ id: crypto.randomUUID(), value: String(value) })), [schema.values])
+  const [values, setValues] = useState(formatedValues)
+  useEffect(() => {
+    setValues(formatedValues)
+  }, [formatedValues])
+
+  const originalInteger = useMemo(() => schema.values.some((value) => typeof value === 'number'), [schema.values])
+  const [integer, setInteger] = useState(originalInteger)
+  useEffect(() => {
+    setInteger(originalInteger)
+  }, [originalInteger])
+
+  const isDirty = useMemo(
+    () => !isEqual(values, formatedValues) || integer !== originalInteger,
+    [values, formatedValues, integer, originalInteger]
+  )
+
+  const saveEnum = useCallback(() => {
+    const list = values.map(({ value }) => value.trim())
+    if (list.some((value) => value === '')) {
+      return toast.error('Values cannot be empty')
+    }
+    if (new Set(list).size !== list.length) {
+      return toast.error('Values must be unique')
+    }
+    if (integer && list.some((value) => !/^-?\d+$/.test(value))) {
+      return toast.error('Values must be integers')
+    }
+    updateSchema(schema.name, SchemaSchema.parse({ ...schema, values: integer ? list.map(Number) : list }))
+  }, [integer, schema, updateSchema, values])
+
+  return (
+    <div className="flex flex-col gap-4 pr-4">
+      <div className="flex items-center justify-between">
+        <h3 className="text-xl font-semibold">{schema.name}</h3>
+        <DeleteSchemaDialog name={schema.name} />
+      </div>
+      <hr />
+      <div className="flex items-center gap-1">
+        <Label>Integer values</Label>
+        <Checkbox checked={integer} onCheckedChange={(checked) => setInteger(!!checked)} />
+      </div>
+      <div className="flex flex-col gap-4">
+        {values.map(({ id, value }) => (
+          <div key={id} className="flex items-center gap-2">
+            <Input
+              value={value}
+              onChange={(e) => setValues((c) => c.map((v) => (v.id === id ? { ...v, value: e.target.value } : v)))}
+            />
+            <button onClick={() => setValues((c) => c.filter((v) => v.id !== id))}>
+              <Delete className="size-4 text-destructive" />
+            </button>
+          </div>
+        ))}
+        <Button size="sm" onClick={() => setValues((c) => [...c, { id: crypto.randomUUID(), value: '' }])}>
+          + Add Value
+        </Button>
+      </div>
+      <div className="flex gap-4 justify-end">
+        <Button
+          disabled={!isDirty}
+          variant="secondary"
+          onClick={() => {
+            setValues(formatedValues)
+            setInteger(originalInteger)
+          }}
+        >
+          Cancel
+        </Button>
+        <Button disabled={!isDirty} onClick={() => saveEnum()}>
+          Save
+        </Button>
+      </div>
+    </div>
+  )
+}
+
+function UpdateUnion({ schema }: { schema: UnionSchemaType }) {
+  const { apex, updateSchema } = useApexStore()
+
+  const formatedVariants = useMemo(
+    () => Object.entries(schema.variants).map(([key, value]) => ({ id: crypto.randomUUID(), key, value })),
+    [schema.variants]
+  )
+  const [variants, setVariants] = useState(formatedVariants)
+  useEffect(() => {
+    setVariants(formatedVariants)
+  }, [formatedVariants])
+
+  const [discriminator, setDiscriminator] = useState(schema.discriminator)
+  useEffect(() => {
+    setDiscriminator(schema.discriminator)
+  }, [schema.discriminator])
+
+  const isDirty = useMemo(
+    () => !isEqual(variants, formatedVariants) || discriminator !== schema.discriminator,
+    [variants, formatedVariants, discriminator, schema.discriminator]
+  )
+
+  const saveUnion = useCallback(() => {
+    const keys = variants.map(({ key }) => key)
+    if (!discriminator) {
+      return toast.error('Discriminator is required')
+    }
+    if (keys.some((key) => key === '') || variants.some(({ value }) => value === '')) {
+      return toast.error('Every variant needs a value and a schema')
+    }
+    if (new Set(keys).size !== keys.length) {
+      return toast.error('Values must be unique')
+    }
+    updateSchema(
+      schema.name,
+      SchemaSchema.parse({ ...schema, discriminator, variants: Object.fromEntries(variants.map(({ key, value }) => [key, value])) })
+    )
+  }, [discriminator, schema, updateSchema, variants])
+
+  return (
+    <div className="flex flex-col gap-4 pr-4">
+      <div className="flex items-center justify-between">
+        <h3 className="text-xl font-semibold">{schema.name}</h3>
+        <DeleteSchemaDialog name={schema.name} />
+      </div>
+      <hr />
+      <div className="flex flex-col gap-2">
+        <Label htmlFor="discriminator">Discriminator</Label>
+        <Input id="discriminator" placeholder="type" value={discriminator} onChange={(e) => setDiscriminator(e.target.value)} />
+      </div>
+      <div className="flex flex-col gap-4">
+        {variants.map(({ id, key, value }) => (
+          <div key={id} className="grid grid-cols-[1fr_1fr_auto] items-center gap-2">
+            <Input
+              placeholder={`${discriminator || 'type'} value`}
+              value={key}
+              onChange={(e) => setVariants((c) => c.map((v) => (v.id === id ? { ...v, key: e.target.value } : v)))}
+            />
+            <Select value={value} onValueChange={(v) => setVariants((c) => c.map((variant) => (variant.id === id ? { ...variant, value: v } : variant)))}>
+              <SelectTrigger>
+                <SelectValue placeholder="Schema" />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectGroup>
+                  <SelectLabel>Schemas</SelectLabel>
+                  {apex.schemas
+                    .filter(({ type }) => type === 'Custom' || type === 'Body' || type === 'Response')
+                    .map(({ name }) => (
+                      <SelectItem key={name} value={name}>
+                        {name}
+                      </SelectItem>
+                    ))}
+                </SelectGroup>
+              </SelectContent>
+            </Select>
+            <button onClick={() => setVariants((c) => c.filter((v) => v.id !== id))}>
+              <Delete className="size-4 text-destructive" />
+            </button>
+          </div>
+        ))}
+        <Button size="sm" onClick={() => setVariants((c) => [...c, { id: crypto.randomUUID(), key: '', value: '' }])}>
+          + Add Variant
+        </Button>
+      </div>
+      <div className="flex gap-4 justify-end">
+        <Button
+          disabled={!isDirty}
+          variant="secondary"
+          onClick={() => {
+            setVariants(formatedVariants)
+            setDiscriminator(schema.discriminator)
+          }}
+        >
+          Cancel
+        </Button>
+        <Button disabled={!isDirty} onClick={() => saveUnion()}>
+          Save
+        </Button>
+      </div>
+    </div>
+  )
+}
@@ -5,6 +5,7 @@ import Go from '@/Go'
 import useDirAndName from '@/hooks/useDirAndName'
 import { cn } from '@/lib/utils'
 import { GetServerStatusSchema, GetServerStatusType } from '@/types/schemas'
+import { EventsOn } from '../../../../wailsjs/runtime/runtime'
 import { createLazyFileRoute } from '@tanstack/react-router'
 import { useCallback, useEffect, useState } from 'react'
 import { toast } from 'sonner'
@@ -56,6 +57,9 @@ function RouteComponent() {
   }, [dir])
   useEffect(refetchPort, [refetchPort])
 
+  // PORT edited in .env outside Genesis
+  useEffect(() => EventsOn('project:env:' + dir, refetchPort), [dir, refetchPort])
+
   function updatePort() {
     if (!port) return toast('Port is empty', { description: 'Port cannot be empty' })
     Go.server.updatePort(dir, port).then(() => {
@@ -1,10 +1,14 @@
 import { z } from 'zod'
 
+export const DatabaseSchema = z.enum(['postgres', 'mysql', 'sqlite'])
+
+export type DatabaseType = z.infer<typeof DatabaseSchema>
+
 export const ProjectJSONSchema = z.object({
   name: z.string(),
   description: z.string().optional(),
 
-  database: z.literal('postgres'),
+  database: DatabaseSchema,
 })
 
 export type ProjectJSONType = z.infer<typeof ProjectJSONSchema>
@@ -24,11 +28,23 @@ export const CreateProjectSchema = z.object({
   dir: z.string(),
   name: z.string(),
   description: z.string().optional(),
-  database: z.literal('postgres'),
+  database: DatabaseSchema,
 })
 
 export type CreateProjectType = z.infer<typeof CreateProjectSchema>
 
+export const GetDatabasesSchema = z
+  .array(
+    z.object({
+      name: DatabaseSchema,
+      label: z.string(),
+      container: z.boolean(),
+    })
+  )
+  .default([])
+
+export type GetDatabasesType = z.infer<typeof GetDatabasesSchema>
+
 export const GetServerStatusSchema = z.object({
   db: z.union([z.literal('running'), z.literal('stopped')]),
   server: z.union([z.literal('running'), z.literal('stopped')]),
@@ -65,3 +81,48 @@ export const GetSQLHistorySchema = z.object({
 
 export type GetSQLHistoryType = z.infer<typeof GetSQLHistorySchema>
 export type SQLQueryType = z.infer<typeof SQLQuerySchema>
+
+export const ApexHistorySchema = z
+  .array(
+    z.object({
+      revision: z.string(),
+      savedAt: z.string(),
+      endpoints: z.number(),
+      schemas: z.number(),
+      operations: z.number(),
+      current: z.boolean(),
+    })
+  )
+  .default([])
+
+export type ApexHistoryType = z.infer<typeof ApexHistorySchema>
+
+export const ApexDiffSchema = z.object({
+  from: z.string(),
+  to: z.string(),
+  changes: z
+    .array(
+      z.object({
+        section: z.enum(['endpoints', 'schemas', 'operations']),
+        name: z.string(),
+        change: z.enum(['added', 'removed', 'changed']),
+      })
+    )
+    .default([]),
+})
+
+export type ApexDiffType = z.infer<typeof ApexDiffSchema>
+
+const ApexDiagnosticSchema = z.object({
+  severity: z.enum(['error', 'warning']),
+  path: z.string(),
+  message: z.string(),
+})
+
+export const ApexValidationSchema = z.object({
+  valid: z.boolean(),
+  errors: z.array(ApexDiagnosticSchema),
+  warnings: z.array(ApexDiagnosticSchema),
+})
+
+export type ApexValidationType = z.infer<typeof ApexValidationSchema>
@@ -24,6 +24,8 @@ export function DeleteSQLQuery(arg1:string,arg2:string):Promise<void>;
 
 export function DeleteStash(arg1:string,arg2:number):Promise<void>;
 
+export function DiffApexRevisions(arg1:string,arg2:string,arg3:string):Promise<main.ApexDiff>;
+
 export function DiscardChanges(arg1:string):Promise<void>;
 
 export function DisconnectDB():Promise<void>;
@@ -42,12 +44,18 @@ export function GetAllStashes(arg1:string):Promise<Array<main.GitStashItem>>;
 
 export function GetApex(arg1:string):Promise<main.ApexData>;
 
+export function GetApexDocument(arg1:string):Promise<main.ApexDocument>;
+
+export function GetApexHistory(arg1:string):Promise<Array<main.ApexRevision>>;
+
 export function GetCommitHistory(arg1:string,arg2:number):Promise<Array<string>>;
 
 export function GetCurrentBranch(arg1:string):Promise<string>;
 
 export function GetDSN(arg1:string):Promise<string>;
 
+export function GetDatabases():Promise<Array<main.DatabaseOption>>;
+
 export function GetDevServersStatus(arg1:string):Promise<main.DevServerStatus>;
 
 export function GetEnvVariable(arg1:string):Promise<string>;
@@ -84,10 +92,14 @@ export function PickGenesisPath():Promise<string>;
 
 export function RestartServer(arg1:string):Promise<void>;
 
+export function RestoreApexRevision(arg1:string,arg2:string):Promise<string>;
+
 export function RunBash(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function SaveApex(arg1:string,arg2:main.ApexData):Promise<void>;
 
+export function SaveApexDocument(arg1:string,arg2:main.ApexData,arg3:string):Promise<string>;
+
 export function SaveEnvVariable(arg1:string,arg2:string):Promise<void>;
 
 export function SaveSQLQuery(arg1:string,arg2:string):Promise<void>;
@@ -105,3 +117,5 @@ export function StopServer(arg1:string):Promise<void>;
 export function SwitchBranch(arg1:string,arg2:string):Promise<void>;
 
 export function UpdatePort(arg1:string,arg2:string):Promise<void>;
+
+export function ValidateApex(arg1:string):Promise<main.ApexValidation>;
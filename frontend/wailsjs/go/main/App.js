@@ -46,6 +46,10 @@ export function DeleteStash(arg1, arg2) {
   return window['go']['main']['App']['DeleteStash'](arg1, arg2);
 }
 
+export function DiffApexRevisions(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DiffApexRevisions'](arg1, arg2, arg3);
+}
+
 export function DiscardChanges(arg1) {
   return window['go']['main']['App']['DiscardChanges'](arg1);
 }
@@ -82,6 +86,14 @@ export function GetApex(arg1) {
   return window['go']['main']['App']['GetApex'](arg1);
 }
 
+export function GetApexDocument(arg1) {
+  return window['go']['main']['App']['GetApexDocument'](arg1);
+}
+
+export function GetApexHistory(arg1) {
+  return window['go']['main']['App']['GetApexHistory'](arg1);
+}
+
 export function GetCommitHistory(arg1, arg2) {
   return window['go']['main']['App']['GetCommitHistory'](arg1, arg2);
 }
@@ -94,6 +106,10 @@ export function GetDSN(arg1) {
   return window['go']['main']['App']['GetDSN'](arg1);
 }
 
+export function GetDatabases() {
+  return window['go']['main']['App']['GetDatabases']();
+}
+
 export function GetDevServersStatus(arg1) {
   return window['go']['main']['App']['GetDevServersStatus'](arg1);
 }
@@ -166,6 +182,10 @@ export function RestartServer(arg1) {
   return window['go']['main']['App']['RestartServer'](arg1);
 }
 
+export function RestoreApexRevision(arg1, arg2) {
+  return window['go']['main']['App']['RestoreApexRevision'](arg1, arg2);
+}
+
 export function RunBash(arg1, arg2, arg3) {
   return window['go']['main']['App']['RunBash'](arg1, arg2, arg3);
 }
@@ -174,6 +194,10 @@ export function SaveApex(arg1, arg2) {
   return window['go']['main']['App']['SaveApex'](arg1, arg2);
 }
 
+export function SaveApexDocument(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SaveApexDocument'](arg1, arg2, arg3);
+}
+
 export function SaveEnvVariable(arg1, arg2) {
   return window['go']['main']['App']['SaveEnvVariable'](arg1, arg2);
 }
@@ -209,3 +233,7 @@ export function SwitchBranch(arg1, arg2) {
 export function UpdatePort(arg1, arg2) {
   return window['go']['main']['App']['UpdatePort'](arg1, arg2);
 }
+
+export function ValidateApex(arg1) {
+  return window['go']['main']['App']['ValidateApex'](arg1);
+}
@@ -4,6 +4,7 @@ export namespace main {
 	    name: string;
 	    endpoint: string;
 	    method: string;
+	    pathSchema?: string;
 	    querySchema?: string;
 	    bodySchema?: string;
 	    responseSchema?: string;
@@ -17,6 +18,7 @@ export namespace main {
 	        this.name = source["name"];
 	        this.endpoint = source["endpoint"];
 	        this.method = source["method"];
+	        this.pathSchema = source["pathSchema"];
 	        this.querySchema = source["querySchema"];
 	        this.bodySchema = source["bodySchema"];
 	        this.responseSchema = source["responseSchema"];
@@ -27,6 +29,9 @@ export namespace main {
 	    type: string;
 	    fields: number[];
 	    required?: string[];
+	    values?: number[][];
+	    discriminator?: string;
+	    variants?: Record<string, string>;
 	
 	    static createFrom(source: any = {}) {
 	        return new Schema(source);
@@ -38,6 +43,9 @@ export namespace main {
 	        this.type = source["type"];
 	        this.fields = source["fields"];
 	        this.required = source["required"];
+	        this.values = source["values"];
+	        this.discriminator = source["discriminator"];
+	        this.variants = source["variants"];
 	    }
 	}
 	export class Endpoint {
@@ -57,6 +65,7 @@ export namespace main {
 	    }
 	}
 	export class ApexData {
+	    version: number;
 	    endpoints: Endpoint[];
 	    schemas: Schema[];
 	    operations: Operation[];
@@ -67,6 +76,7 @@ export namespace main {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
 	        this.endpoints = this.convertValues(source["endpoints"], Endpoint);
 	        this.schemas = this.convertValues(source["schemas"], Schema);
 	        this.operations = this.convertValues(source["operations"], Operation);
@@ -90,6 +100,160 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class ApexDocument {
+	    apex?: ApexData;
+	    revision: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApexDocument(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.apex = this.convertValues(source["apex"], ApexData);
+	        this.revision = source["revision"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ApexChange {
+	    section: string;
+	    name: string;
+	    change: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApexChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.section = source["section"];
+	        this.name = source["name"];
+	        this.change = source["change"];
+	    }
+	}
+	export class ApexDiagnostic {
+	    severity: string;
+	    path: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApexDiagnostic(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	        this.path = source["path"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ApexDiff {
+	    from: string;
+	    to: string;
+	    changes: ApexChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ApexDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.changes = this.convertValues(source["changes"], ApexChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ApexRevision {
+	    revision: string;
+	    savedAt: string;
+	    endpoints: number;
+	    schemas: number;
+	    operations: number;
+	    current: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApexRevision(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.revision = source["revision"];
+	        this.savedAt = source["savedAt"];
+	        this.endpoints = source["endpoints"];
+	        this.schemas = source["schemas"];
+	        this.operations = source["operations"];
+	        this.current = source["current"];
+	    }
+	}
+	export class ApexValidation {
+	    valid: boolean;
+	    errors: ApexDiagnostic[];
+	    warnings: ApexDiagnostic[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ApexValidation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.valid = source["valid"];
+	        this.errors = this.convertValues(source["errors"], ApexDiagnostic);
+	        this.warnings = this.convertValues(source["warnings"], ApexDiagnostic);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ClientApp {
 	    type: string;
 	    exists: boolean;
@@ -104,6 +268,22 @@ export namespace main {
 	        this.exists = source["exists"];
 	    }
 	}
+	export class DatabaseOption {
+	    name: string;
+	    label: string;
+	    container: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseOption(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.label = source["label"];
+	        this.container = source["container"];
+	    }
+	}
 	export class DevServerStatus {
 	    web: boolean;
 	    mobile: boolean;
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// serverHealth tracks the readiness of one server and owns its monitor goroutine
+type serverHealth struct {
+	mu        sync.Mutex
+	state     string
+	port      string
+	message   string
+	updatedAt time.Time
+	stop      chan struct{}
+}
+
+func (h *serverHealth) snapshot() ServerHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return ServerHealth{
+		State:     h.state,
+		Port:      h.port,
+		Message:   h.message,
+		UpdatedAt: h.updatedAt.Format(time.RFC3339),
+	}
+}
+
+func defaultHealthCheck(config *HealthCheckConfig) HealthCheckConfig {
+	result := HealthCheckConfig{Type: "tcp", Timeout: 60, Interval: 500}
+	if config == nil {
+		return result
+	}
+
+	if config.Type != "" {
+		result.Type = config.Type
+	}
+	if config.Path != "" {
+		result.Path = config.Path
+	}
+	if config.Timeout > 0 {
+		result.Timeout = config.Timeout
+	}
+	if config.Interval > 0 {
+		result.Interval = config.Interval
+	}
+	return result
+}
+
+// setServerState records a state change and pushes it to the frontend
+func (a *App) setServerState(dir string, health *serverHealth, state, message string) {
+	health.mu.Lock()
+	changed := health.state != state || health.message != message
+	health.state = state
+	health.message = message
+	health.updatedAt = time.Now()
+	health.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	fmt.Println("🩺 Server for", dir, "is", state, message)
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "server:health:"+dir, health.snapshot())
+	}
+}
+
+// monitorServer probes a freshly started server until it is ready, then keeps watching it
+// until it exits or the monitor is stopped.
+func (a *App) monitorServer(dir string, proc *managedProcess, config HealthCheckConfig, port string) {
+	health := &serverHealth{port: port, stop: make(chan struct{})}
+
+	a.procMu.Lock()
+	if previous, exists := a.health[dir]; exists {
+		close(previous.stop)
+	}
+	a.health[dir] = health
+	a.procMu.Unlock()
+
+	a.setServerState(dir, health, ServerStarting, "")
+
+	interval := time.Duration(config.Interval) * time.Millisecond
+	deadline := time.Now().Add(time.Duration(config.Timeout) * time.Second)
+	ready := false
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-health.stop:
+				return
+			case <-proc.done:
+				a.setServerState(dir, health, ServerCrashed, fmt.Sprintf("process exited: %v", proc.err))
+				return
+			case <-ticker.C:
+			}
+
+			err := probeServer(config, port)
+			switch {
+			case err == nil:
+				if !ready {
+					ready = true
+					// Once ready, there's no need to hammer the server
+					ticker.Reset(5 * time.Second)
+				}
+				a.setServerState(dir, health, ServerReady, "")
+			case ready:
+				a.setServerState(dir, health, ServerUnhealthy, err.Error())
+			case time.Now().After(deadline):
+				a.setServerState(dir, health, ServerUnhealthy, fmt.Sprintf("not ready after %ds: %v", config.Timeout, err))
+			}
+		}
+	}()
+}
+
+// stopServerMonitor stops watching a server and marks it stopped
+func (a *App) stopServerMonitor(dir string) {
+	a.procMu.Lock()
+	health, exists := a.health[dir]
+	delete(a.health, dir)
+	a.procMu.Unlock()
+
+	if !exists {
+		return
+	}
+
+	close(health.stop)
+	a.setServerState(dir, health, ServerStopped, "")
+}
+
+// probeServer checks once whether the server accepts connections (tcp) or answers below 500 (http)
+func probeServer(config HealthCheckConfig, port string) error {
+	address := net.JoinHostPort("localhost", port)
+
+	if config.Type == "http" {
+		client := http.Client{Timeout: 2 * time.Second}
+		resp, err := client.Get("http://" + address + config.Path)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("health check returned %s", resp.Status)
+		}
+		return nil
+	}
+
+	conn, err := net.DialTimeout("tcp", address, 2*time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Server readiness states
+const (
+	ServerStopped   = "stopped"
+	ServerStarting  = "starting"
+	ServerReady     = "ready"
+	ServerUnhealthy = "unhealthy"
+	ServerCrashed   = "crashed"
+)
+
+// HealthCheckConfig configures how Genesis decides a solar system's server is serving.
+// It is stored under "health" in project.json.
+type HealthCheckConfig struct {
+	Type     string `json:"type"`     // "tcp" | "http"
+	Path     string `json:"path"`     // HTTP path to GET when Type is "http", e.g. "/health"
+	Timeout  int    `json:"timeout"`  // Seconds to wait for the server to become ready
+	Interval int    `json:"interval"` // Milliseconds between probes
+}
+
+// ServerHealth is the last known readiness of a server
+type ServerHealth struct {
+	State     string `json:"state"`
+	Port      string `json:"port"`
+	Message   string `json:"message"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// GetServerHealth returns the readiness state Genesis is tracking for a server
+func (a *App) GetServerHealth(dir string) ServerHealth {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	if health, exists := a.health[dir]; exists {
+		return health.snapshot()
+	}
+	return ServerHealth{State: ServerStopped}
+}
+
+// GetHealthCheck returns the health check configuration of a project, with defaults applied
+func (a *App) GetHealthCheck(dir string) HealthCheckConfig {
+	projectData, err := readProjectJSON(filepath.Join(getSolarDir(a.ProjectsDir), dir, "project.json"))
+	if err != nil || projectData.Health == nil {
+		return defaultHealthCheck(nil)
+	}
+	return defaultHealthCheck(projectData.Health)
+}
+
+// SaveHealthCheck stores the health check configuration in project.json
+func (a *App) SaveHealthCheck(dir string, config HealthCheckConfig) error {
+	if config.Type != "tcp" && config.Type != "http" {
+		return fmt.Errorf("❌ Invalid health check type: %s. Must be 'tcp' or 'http'", config.Type)
+	}
+
+	projectFilePath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "project.json")
+	unlock := lockFile(projectFilePath)
+	defer unlock()
+
+	projectData, err := readProjectJSONUnlocked(projectFilePath)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to read project.json: %v", err)
+	}
+
+	projectData.Health = &config
+	if err := writeProjectJSONUnlocked(projectFilePath, projectData); err != nil {
+		return fmt.Errorf("❌ Failed to write project.json: %v", err)
+	}
+
+	fmt.Println("✅ Health check saved for project:", dir)
+	return nil
+}
+
+// WaitForServerReady blocks until the server is ready, unhealthy, crashed or the timeout elapses.
+// A timeout of 0 uses the project's configured timeout.
+func (a *App) WaitForServerReady(dir string, timeoutSeconds int) (string, error) {
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = a.GetHealthCheck(dir).Timeout
+	}
+	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+
+	for {
+		state := a.GetServerHealth(dir).State
+		switch state {
+		case ServerReady:
+			return state, nil
+		case ServerCrashed, ServerUnhealthy, ServerStopped:
+			return state, fmt.Errorf("❌ Server for %s is %s", dir, state)
+		}
+
+		if time.Now().After(deadline) {
+			return state, fmt.Errorf("❌ Timeout: Server for %s did not become ready in time", dir)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var defaultWatchIgnore = []string{".git", ".genesis", "node_modules", "tmp", "bin", "*.log", "*~", ".*.swp"}
+
+// serverWatcher rebuilds and restarts one server on file changes
+type serverWatcher struct {
+	app         *App
+	dir         string
+	projectPath string
+	root        string // projectPath with symlinks resolved, as fsnotify reports it
+	debounce    time.Duration
+	ignore      []string
+	watcher     *fsnotify.Watcher
+	stop        chan struct{}
+
+	mu        sync.Mutex
+	building  bool
+	pending   bool // Files changed during the current build
+	lastBuild *BuildResult
+}
+
+func newServerWatcher(a *App, dir, projectPath string, opts ServerWatchOptions) (*serverWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	debounce := time.Duration(opts.Debounce) * time.Millisecond
+	if debounce <= 0 {
+		debounce = 300 * time.Millisecond
+	}
+
+	// Imported projects can be symlinked in, and WalkDir doesn't descend into a symlinked root
+	root := projectPath
+	if resolved, err := filepath.EvalSymlinks(projectPath); err == nil {
+		root = resolved
+	}
+
+	w := &serverWatcher{
+		app:         a,
+		dir:         dir,
+		projectPath: projectPath,
+		root:        root,
+		debounce:    debounce,
+		ignore:      append(append([]string{}, defaultWatchIgnore...), opts.Ignore...),
+		watcher:     watcher,
+		stop:        make(chan struct{}),
+	}
+
+	if err := w.addTree(root); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// addTree watches root and every non-ignored directory below it
+func (w *serverWatcher) addTree(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && w.ignored(path) {
+			return filepath.SkipDir
+		}
+		return w.watcher.Add(path)
+	})
+}
+
+// ignored reports whether a path matches any ignore pattern
+func (w *serverWatcher) ignored(path string) bool {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, pattern := range w.ignore {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		for _, segment := range strings.Split(rel, "/") {
+			if ok, _ := filepath.Match(pattern, segment); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w *serverWatcher) run() {
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			timer.Stop()
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.ignored(event.Name) {
+				continue
+			}
+
+			// New directories need their own watch
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					w.addTree(event.Name)
+				}
+			}
+
+			timer.Reset(w.debounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			fmt.Println("⚠️ Watcher error:", err)
+		case <-timer.C:
+			// Build in the background so events keep being drained meanwhile
+			go w.rebuild()
+		}
+	}
+}
+
+// rebuild builds the server, or queues a single follow-up build when one is already running so
+// changes saved mid-build aren't lost
+func (w *serverWatcher) rebuild() {
+	w.mu.Lock()
+	if w.building {
+		w.pending = true
+		w.mu.Unlock()
+		return
+	}
+	w.building = true
+	w.mu.Unlock()
+
+	for {
+		w.buildAndSwap()
+
+		w.mu.Lock()
+		again := w.pending
+		w.pending = false
+		select {
+		case <-w.stop:
+			again = false
+		default:
+		}
+		w.building = again
+		w.mu.Unlock()
+		if !again {
+			return
+		}
+	}
+}
+
+// buildAndSwap compiles the server into a fresh binary and swaps it in on success
+func (w *serverWatcher) buildAndSwap() {
+	w.app.appendLog(serverProcessID(w.dir), "stdout", "🔄 Change detected, rebuilding...")
+
+	// BuildServer writes a new versioned binary, so the running one is never overwritten
+	result, err := w.app.BuildServer(w.dir, BuildOptions{})
+	if err != nil {
+		w.app.appendLog(serverProcessID(w.dir), "stderr", err.Error())
+		return
+	}
+
+	w.mu.Lock()
+	w.lastBuild = &result
+	w.mu.Unlock()
+
+	if !result.Success {
+		w.app.appendLog(serverProcessID(w.dir), "stderr", fmt.Sprintf("❌ Build failed with %d errors, keeping the running server", len(result.Diagnostics)))
+		return
+	}
+
+	if err := w.app.swapServerProcess(w.dir, w.projectPath, result.BinaryPath); err != nil {
+		w.app.appendLog(serverProcessID(w.dir), "stderr", err.Error())
+	}
+}
+
+func (w *serverWatcher) status() ServerWatchStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return ServerWatchStatus{
+		Watching:  true,
+		Building:  w.building,
+		LastBuild: w.lastBuild,
+	}
+}
+
+func (w *serverWatcher) close() {
+	close(w.stop)
+	w.watcher.Close()
+}
+
+// swapServerProcess replaces the running server with binary, leaving Docker Compose untouched
+func (a *App) swapServerProcess(dir, projectPath, binary string) error {
+	a.stopServerMonitor(dir)
+	a.stopManagedProcess(serverProcessID(dir))
+	if err := killServerProcesses(dir, projectPath); err != nil {
+		return err
+	}
+
+	port := readEnvValue(filepath.Join(projectPath, ".env"), "PORT", defaultServerPort(dir))
+	cmd := exec.Command(binary)
+	cmd.Dir = projectPath
+	cmd.Env = append(os.Environ(), "PORT="+port)
+
+	proc, err := a.startManagedProcess(serverProcessID(dir), cmd)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to start rebuilt server: %v", err)
+	}
+
+	a.monitorServer(dir, proc, a.GetHealthCheck(dir), port)
+
+	fmt.Println("✅ Swapped in rebuilt server for:", dir)
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ServerWatchOptions configures hot reload of a solar system's server
+type ServerWatchOptions struct {
+	Debounce int      `json:"debounce"` // Milliseconds to wait for changes to settle, defaults to 300
+	Ignore   []string `json:"ignore"`   // Glob patterns matched against each path segment and the relative path
+}
+
+// ServerWatchStatus reports whether a server is being watched and how its last rebuild went
+type ServerWatchStatus struct {
+	Watching  bool         `json:"watching"`
+	Building  bool         `json:"building"`
+	LastBuild *BuildResult `json:"lastBuild"`
+}
+
+// StartServerWatch rebuilds the server whenever files in the -star tree change and swaps in
+// the new binary once it compiles. On compile errors the running server is left untouched.
+func (a *App) StartServerWatch(dir string, opts ServerWatchOptions) error {
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir, dir+"-star")
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return fmt.Errorf("❌ Project directory does not exist: %s", projectPath)
+	}
+
+	a.procMu.Lock()
+	_, exists := a.watchers[dir]
+	a.procMu.Unlock()
+	if exists {
+		return fmt.Errorf("⚠️ Server for %s is already being watched", dir)
+	}
+
+	watcher, err := newServerWatcher(a, dir, projectPath, opts)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to start watching server: %v", err)
+	}
+
+	a.procMu.Lock()
+	a.watchers[dir] = watcher
+	a.procMu.Unlock()
+
+	go watcher.run()
+
+	fmt.Println("👀 Watching server for changes:", projectPath)
+	return nil
+}
+
+// StopServerWatch stops hot reload. The server itself keeps running.
+func (a *App) StopServerWatch(dir string) error {
+	a.procMu.Lock()
+	watcher, exists := a.watchers[dir]
+	delete(a.watchers, dir)
+	a.procMu.Unlock()
+
+	if !exists {
+		fmt.Println("⚠️ Server for", dir, "is not being watched")
+		return nil
+	}
+
+	watcher.close()
+	fmt.Println("✅ Stopped watching server for:", dir)
+	return nil
+}
+
+// GetServerWatchStatus returns the hot reload state of a server
+func (a *App) GetServerWatchStatus(dir string) ServerWatchStatus {
+	a.procMu.Lock()
+	watcher, exists := a.watchers[dir]
+	a.procMu.Unlock()
+
+	if !exists {
+		return ServerWatchStatus{}
+	}
+	return watcher.status()
+}
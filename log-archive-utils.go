@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	logArchiveMaxSize  = 5 * 1024 * 1024 // Rotate once a file reaches 5 MB
+	logArchiveMaxFiles = 5               // Rotated files kept per source
+)
+
+// getGenesisDir returns the per-project folder for Genesis-managed state that stays out of git
+func getGenesisDir(projectPath string) string {
+	genesisDir := filepath.Join(projectPath, ".genesis")
+	if err := ensureDir(genesisDir); err == nil {
+		gitignore := filepath.Join(genesisDir, ".gitignore")
+		if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+			os.WriteFile(gitignore, []byte("*\n"), 0644)
+		}
+	}
+	return genesisDir
+}
+
+func getLogsDir(projectPath string) string {
+	return filepath.Join(projectPath, ".genesis", "logs")
+}
+
+// logArchive appends JSON lines to a size-rotated file
+type logArchive struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+}
+
+// archiveLog persists a line to the archive of its source in the given project
+func (a *App) archiveLog(dir string, line LogLine) {
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	if _, err := os.Stat(projectPath); err != nil {
+		return
+	}
+
+	path := filepath.Join(getLogsDir(projectPath), line.Source+".log")
+
+	a.procMu.Lock()
+	archive, exists := a.archives[path]
+	if !exists {
+		getGenesisDir(projectPath)
+		archive = &logArchive{path: path}
+		a.archives[path] = archive
+	}
+	a.procMu.Unlock()
+
+	if err := archive.write(line); err != nil {
+		fmt.Println("⚠️ Failed to archive log line:", err)
+	}
+}
+
+func (l *logArchive) write(line LogLine) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if l.file == nil {
+		if err := l.open(); err != nil {
+			return err
+		}
+	}
+
+	if l.size+int64(len(data)) > logArchiveMaxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	return err
+}
+
+func (l *logArchive) open() error {
+	if err := ensureDir(filepath.Dir(l.path)); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotate shifts server.log → server.1.log → … and drops the oldest file
+func (l *logArchive) rotate() error {
+	l.file.Close()
+	l.file = nil
+
+	base := strings.TrimSuffix(l.path, ".log")
+	os.Remove(fmt.Sprintf("%s.%d.log", base, logArchiveMaxFiles))
+	for i := logArchiveMaxFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d.log", base, i), fmt.Sprintf("%s.%d.log", base, i+1))
+	}
+	if err := os.Rename(l.path, base+".1.log"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return l.open()
+}
+
+// archiveFiles lists the archive files of a project, oldest rotation first
+func archiveFiles(logsDir string) ([]string, error) {
+	entries, err := os.ReadDir(logsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type archiveFile struct {
+		path     string
+		rotation int
+	}
+
+	var files []archiveFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+
+		rotation := 0
+		parts := strings.Split(strings.TrimSuffix(name, ".log"), ".")
+		if len(parts) > 1 {
+			if n, err := strconv.Atoi(parts[len(parts)-1]); err == nil {
+				rotation = n
+			}
+		}
+		files = append(files, archiveFile{path: filepath.Join(logsDir, name), rotation: rotation})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].rotation > files[j].rotation
+	})
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.path
+	}
+	return paths, nil
+}
+
+// readArchiveFile calls fn for every well-formed line in an archive file
+func readArchiveFile(path string, fn func(LogLine)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line LogLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		fn(line)
+	}
+	return scanner.Err()
+}
+
+// closeLogArchives closes the open archive files of a project before it is moved or removed
+func (a *App) closeLogArchives(dir string) {
+	prefix := getLogsDir(filepath.Join(getSolarDir(a.ProjectsDir), dir)) + string(filepath.Separator)
+
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	for path, archive := range a.archives {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+
+		archive.mu.Lock()
+		if archive.file != nil {
+			archive.file.Close()
+			archive.file = nil
+		}
+		archive.mu.Unlock()
+		delete(a.archives, path)
+	}
+}
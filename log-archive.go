@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogSearchFilters narrows down a SearchLogs query
+type LogSearchFilters struct {
+	Regex         bool     `json:"regex"`         // Treat the query as a regular expression
+	CaseSensitive bool     `json:"caseSensitive"` // Substring/regex matching is case-insensitive by default
+	Since         string   `json:"since"`         // RFC3339, inclusive
+	Until         string   `json:"until"`         // RFC3339, inclusive
+	Sources       []string `json:"sources"`       // "server", "web", "mobile", "desktop", "docker"
+	Levels        []string `json:"levels"`        // "debug", "info", "warn", "error"
+	Limit         int      `json:"limit"`         // Most recent matches to return, defaults to 1000
+}
+
+// SearchLogs searches the persisted log archive of a project, oldest match first
+func (a *App) SearchLogs(dir, query string, filters LogSearchFilters) ([]LogLine, error) {
+	if len(dir) == 0 {
+		return nil, fmt.Errorf("❌ Project directory cannot be empty")
+	}
+
+	matchText, err := logQueryMatcher(query, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var since, until time.Time
+	if filters.Since != "" {
+		if since, err = time.Parse(time.RFC3339, filters.Since); err != nil {
+			return nil, fmt.Errorf("❌ Invalid 'since' time: %v", err)
+		}
+	}
+	if filters.Until != "" {
+		if until, err = time.Parse(time.RFC3339, filters.Until); err != nil {
+			return nil, fmt.Errorf("❌ Invalid 'until' time: %v", err)
+		}
+	}
+
+	sources := toSet(filters.Sources)
+	levels := toSet(filters.Levels)
+
+	logsDir := getLogsDir(filepath.Join(getSolarDir(a.ProjectsDir), dir))
+	files, err := archiveFiles(logsDir)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to list log archive: %v", err)
+	}
+
+	results := []LogLine{}
+	for _, file := range files {
+		err := readArchiveFile(file, func(line LogLine) {
+			if len(sources) > 0 && !sources[line.Source] {
+				return
+			}
+			if len(levels) > 0 && !levels[line.Level] {
+				return
+			}
+			if !since.IsZero() || !until.IsZero() {
+				timestamp, err := time.Parse(time.RFC3339Nano, line.Timestamp)
+				if err != nil {
+					return
+				}
+				if !since.IsZero() && timestamp.Before(since) {
+					return
+				}
+				if !until.IsZero() && timestamp.After(until) {
+					return
+				}
+			}
+			if !matchText(line.Text) {
+				return
+			}
+			results = append(results, line)
+		})
+		if err != nil {
+			fmt.Println("⚠️ Skipping unreadable log file:", file, err)
+		}
+	}
+
+	// Timestamps drop trailing zeros of the fraction, so they don't sort as strings
+	sort.SliceStable(results, func(i, j int) bool {
+		first, _ := time.Parse(time.RFC3339Nano, results[i].Timestamp)
+		second, _ := time.Parse(time.RFC3339Nano, results[j].Timestamp)
+		return first.Before(second)
+	})
+
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	if len(results) > limit {
+		results = results[len(results)-limit:]
+	}
+
+	fmt.Println("✅ Found", len(results), "log lines matching", fmt.Sprintf("%q", query), "in project", dir)
+	return results, nil
+}
+
+// logQueryMatcher builds the text predicate for a search query
+func logQueryMatcher(query string, filters LogSearchFilters) (func(string) bool, error) {
+	if query == "" {
+		return func(string) bool { return true }, nil
+	}
+
+	if filters.Regex {
+		pattern := query
+		if !filters.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("❌ Invalid regular expression: %v", err)
+		}
+		return re.MatchString, nil
+	}
+
+	if filters.CaseSensitive {
+		return func(text string) bool { return strings.Contains(text, query) }, nil
+	}
+	lowerQuery := strings.ToLower(query)
+	return func(text string) bool { return strings.Contains(strings.ToLower(text), lowerQuery) }, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const logBufferSize = 5000
+
+// logBuffer is a fixed-size ring of the most recent lines of a process
+type logBuffer struct {
+	mu      sync.Mutex
+	lines   []LogLine
+	start   int
+	count   int
+	nextSeq int64
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{lines: make([]LogLine, size)}
+}
+
+// append stores a line, overwriting the oldest one once the buffer is full
+func (b *logBuffer) append(line LogLine) LogLine {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextSeq++
+	line.Seq = b.nextSeq
+
+	index := (b.start + b.count) % len(b.lines)
+	b.lines[index] = line
+	if b.count < len(b.lines) {
+		b.count++
+	} else {
+		b.start = (b.start + 1) % len(b.lines)
+	}
+	return line
+}
+
+// since returns every buffered line with a sequence number greater than seq
+func (b *logBuffer) since(seq int64) []LogLine {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := []LogLine{}
+	for i := 0; i < b.count; i++ {
+		line := b.lines[(b.start+i)%len(b.lines)]
+		if line.Seq > seq {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func (b *logBuffer) clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.start = 0
+	b.count = 0
+}
+
+// managedProcess is a child process whose output is captured into a log buffer
+type managedProcess struct {
+	id   string
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+}
+
+func (p *managedProcess) running() bool {
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// getLogBuffer returns the buffer of a process, creating it on first use.
+// Buffers outlive their process so output from before a restart stays readable.
+func (a *App) getLogBuffer(processID string) *logBuffer {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	buffer, exists := a.logs[processID]
+	if !exists {
+		buffer = newLogBuffer(logBufferSize)
+		a.logs[processID] = buffer
+	}
+	return buffer
+}
+
+// appendLog stores a line for a process and pushes it to the frontend
+func (a *App) appendLog(processID, stream, text string) LogLine {
+	dir, source := processSource(processID)
+	line := a.getLogBuffer(processID).append(LogLine{
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Process:   processID,
+		Source:    source,
+		Stream:    stream,
+		Level:     detectLogLevel(text),
+		Text:      text,
+	})
+
+	if dir != "" {
+		a.archiveLog(dir, line)
+	}
+
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, logEventName(processID), line)
+	}
+	return line
+}
+
+// startManagedProcess starts cmd and streams its stdout and stderr into the log buffer of processID
+func (a *App) startManagedProcess(processID string, cmd *exec.Cmd) (*managedProcess, error) {
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to capture stdout: %v", err)
+	}
+
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to capture stderr: %v", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	proc := &managedProcess{
+		id:   processID,
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+
+	a.procMu.Lock()
+	a.processes[processID] = proc
+	a.procMu.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go a.scanProcessOutput(&wg, processID, "stdout", stdoutPipe)
+	go a.scanProcessOutput(&wg, processID, "stderr", stderrPipe)
+
+	go func() {
+		// Pipes must be drained before Wait closes them
+		wg.Wait()
+		proc.err = cmd.Wait()
+		close(proc.done)
+
+		if proc.err != nil {
+			a.appendLog(processID, "stderr", fmt.Sprintf("❌ Process exited: %v", proc.err))
+		} else {
+			a.appendLog(processID, "stdout", "✅ Process exited")
+		}
+	}()
+
+	return proc, nil
+}
+
+func (a *App) scanProcessOutput(wg *sync.WaitGroup, processID, stream string, reader io.Reader) {
+	defer wg.Done()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		a.appendLog(processID, stream, scanner.Text())
+	}
+}
+
+// stopManagedProcess kills a tracked process, if any, and waits briefly for it to exit
+func (a *App) stopManagedProcess(processID string) {
+	a.procMu.Lock()
+	proc, exists := a.processes[processID]
+	delete(a.processes, processID)
+	a.procMu.Unlock()
+
+	if !exists || !proc.running() {
+		return
+	}
+
+	fmt.Println("🔪 Killing managed process:", processID)
+	if err := proc.cmd.Process.Kill(); err != nil {
+		fmt.Println("⚠️ Failed to kill process:", err)
+		return
+	}
+
+	select {
+	case <-proc.done:
+	case <-time.After(5 * time.Second):
+		fmt.Println("⚠️ Timed out waiting for process to exit:", processID)
+	}
+}
+
+// isManagedProcessRunning reports whether Genesis has a live process tracked under processID
+func (a *App) isManagedProcessRunning(processID string) bool {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	proc, exists := a.processes[processID]
+	return exists && proc.running()
+}
+
+// waitForLogMatch waits until match returns a non-empty value for the joined output of a
+// process, the process exits or the timeout elapses. It returns the match and the output.
+func (a *App) waitForLogMatch(proc *managedProcess, since int64, timeout time.Duration, match func(string) string) (string, string) {
+	buffer := a.getLogBuffer(proc.id)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		output := ""
+		for _, line := range buffer.since(since) {
+			output += line.Text + "\n"
+		}
+
+		if found := match(output); found != "" {
+			return found, output
+		}
+		if !proc.running() || time.Now().After(deadline) {
+			return "", output
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// lastLogSeq returns the sequence number of the newest line of a process
+func (a *App) lastLogSeq(processID string) int64 {
+	buffer := a.getLogBuffer(processID)
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	return buffer.nextSeq
+}
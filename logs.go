@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogLine is a single line of output captured from a managed process
+type LogLine struct {
+	Seq       int64  `json:"seq"`
+	Timestamp string `json:"timestamp"`
+	Process   string `json:"process"`
+	Source    string `json:"source"` // "server" | "web" | "mobile" | "desktop" | "docker"
+	Stream    string `json:"stream"` // "stdout" | "stderr"
+	Level     string `json:"level"`  // "debug" | "info" | "warn" | "error"
+	Text      string `json:"text"`
+}
+
+// ProcessInfo describes a process Genesis has started and is tracking
+type ProcessInfo struct {
+	ID      string `json:"id"`
+	Running bool   `json:"running"`
+	PID     int    `json:"pid"`
+}
+
+// GetLogs returns the buffered lines for a process with a sequence number greater than `since`.
+// Pass 0 to get everything still held in the buffer.
+func (a *App) GetLogs(processID string, since int64) []LogLine {
+	buffer := a.getLogBuffer(processID)
+	return buffer.since(since)
+}
+
+// ClearLogs empties the log buffer of a process
+func (a *App) ClearLogs(processID string) {
+	a.getLogBuffer(processID).clear()
+	fmt.Println("🧹 Cleared logs for:", processID)
+}
+
+// GetProcesses lists every process Genesis is tracking
+func (a *App) GetProcesses() []ProcessInfo {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	processes := []ProcessInfo{}
+	for id, proc := range a.processes {
+		info := ProcessInfo{ID: id, Running: proc.running()}
+		if proc.cmd.Process != nil {
+			info.PID = proc.cmd.Process.Pid
+		}
+		processes = append(processes, info)
+	}
+	return processes
+}
+
+// serverProcessID identifies the Go server of a solar system
+func serverProcessID(dir string) string {
+	return "server:" + dir
+}
+
+// devServerProcessID identifies the dev server of a planet
+func devServerProcessID(dir, planetType string) string {
+	return "dev:" + dir + ":" + planetType
+}
+
+// testProcessID identifies a test run of the server ("server") or a planet
+func testProcessID(dir, target string) string {
+	return "test:" + dir + ":" + target
+}
+
+// processSource splits a process ID into the project directory and the log source it belongs to
+func processSource(processID string) (string, string) {
+	parts := strings.Split(processID, ":")
+	switch {
+	case len(parts) == 2 && parts[0] == "server":
+		return parts[1], "server"
+	case len(parts) == 3 && parts[0] == "dev":
+		return parts[1], parts[2]
+	case len(parts) >= 2:
+		return parts[1], parts[0]
+	default:
+		return "", processID
+	}
+}
+
+// logEventName is the Wails event new lines of a process are pushed on
+func logEventName(processID string) string {
+	return "logs:" + processID
+}
+
+// detectLogLevel makes a best-effort guess of the severity of a line
+func detectLogLevel(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "panic"),
+		strings.Contains(lower, "fatal"),
+		strings.Contains(lower, "error"),
+		strings.Contains(lower, "failed"),
+		strings.Contains(lower, "❌"):
+		return "error"
+	case strings.Contains(lower, "warn"),
+		strings.Contains(lower, "⚠️"):
+		return "warn"
+	case strings.Contains(lower, "debug"):
+		return "debug"
+	default:
+		return "info"
+	}
+}
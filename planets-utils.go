@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Vite prints "➜  Local:   http://localhost:5174/"
+const defaultPlanetURLPattern = `Local:\s+(http://localhost:\d+/?)`
+
+// defaultPlanetTypes are available without any planets.json
+var defaultPlanetTypes = []PlanetType{
+	{
+		Name:         "web",
+		Description:  "React web app",
+		Template:     "planet-web",
+		DevCommand:   "npm run dev",
+		PortFlag:     "-- --port",
+		BuildCommand: "npm run build",
+		URLPattern:   defaultPlanetURLPattern,
+		BuiltIn:      true,
+	},
+	{
+		Name:         "mobile",
+		Description:  "Mobile app",
+		Template:     "planet-mobile",
+		DevCommand:   "npm run dev",
+		PortFlag:     "-- --port",
+		BuildCommand: "npm run build",
+		URLPattern:   defaultPlanetURLPattern,
+		BuiltIn:      true,
+	},
+	{
+		Name:         "desktop",
+		Description:  "Desktop app",
+		Template:     "planet-desktop",
+		DevCommand:   "npm run dev",
+		PortFlag:     "-- --port",
+		BuildCommand: "npm run build",
+		URLPattern:   defaultPlanetURLPattern,
+		BuiltIn:      true,
+	},
+}
+
+// Planet type and instance names double as directory names and env key prefixes
+var planetNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// planetConfig is the layout of GENESIS_PATH/genesis/planets.json
+type planetConfig struct {
+	Planets []PlanetType `json:"planets"`
+}
+
+func (a *App) planetConfigPath() string {
+	return filepath.Join(a.ProjectsDir, "genesis", "planets.json")
+}
+
+// readPlanetConfig returns the planet types from planets.json, or none when it doesn't exist
+func (a *App) readPlanetConfig() []PlanetType {
+	data, err := os.ReadFile(a.planetConfigPath())
+	if err != nil {
+		return []PlanetType{}
+	}
+
+	var config planetConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		fmt.Println("⚠️ Ignoring invalid planets.json:", err)
+		return []PlanetType{}
+	}
+	return config.Planets
+}
+
+func (a *App) writePlanetConfig(planets []PlanetType) error {
+	if err := ensureDir(filepath.Dir(a.planetConfigPath())); err != nil {
+		return err
+	}
+	return writeJSON(a.planetConfigPath(), planetConfig{Planets: planets})
+}
+
+// loadPlanetRegistry merges planets.json over the built-in planet types
+func (a *App) loadPlanetRegistry() []PlanetType {
+	registry := append([]PlanetType{}, defaultPlanetTypes...)
+
+	for _, planet := range a.readPlanetConfig() {
+		if !planetNameRegex.MatchString(planet.Name) {
+			fmt.Println("⚠️ Skipping planet type with invalid name in planets.json:", planet.Name)
+			continue
+		}
+
+		replaced := false
+		for i, existing := range registry {
+			if existing.Name == planet.Name {
+				registry[i] = planet
+				replaced = true
+			}
+		}
+		if !replaced {
+			registry = append(registry, planet)
+		}
+	}
+	return registry
+}
+
+func (a *App) findPlanetType(name string) (PlanetType, bool) {
+	for _, planet := range a.loadPlanetRegistry() {
+		if planet.Name == name {
+			return planet, true
+		}
+	}
+	return PlanetType{}, false
+}
+
+func (a *App) planetTypeNames() []string {
+	var names []string
+	for _, planet := range a.loadPlanetRegistry() {
+		names = append(names, planet.Name)
+	}
+	return names
+}
+
+// planetInstanceType returns the type of a planet directory: what project.json recorded when it
+// was added, or else the registered type its name is or starts with ("web-landing" → "web")
+func (a *App) planetInstanceType(dir, name string) (PlanetType, bool) {
+	projectData, err := readProjectJSON(filepath.Join(getSolarDir(a.ProjectsDir), dir, "project.json"))
+	if err == nil {
+		if planetType, exists := projectData.Planets[name]; exists {
+			return a.findPlanetType(planetType)
+		}
+	}
+
+	candidate := name
+	for {
+		if planet, exists := a.findPlanetType(candidate); exists {
+			return planet, true
+		}
+		index := strings.LastIndex(candidate, "-")
+		if index <= 0 {
+			return PlanetType{}, false
+		}
+		candidate = candidate[:index]
+	}
+}
+
+// resolvePlanet validates a planet name and returns its type and directory
+func (a *App) resolvePlanet(dir, name string) (PlanetType, string, error) {
+	if !planetNameRegex.MatchString(name) {
+		return PlanetType{}, "", fmt.Errorf("❌ Invalid planet name: %s", name)
+	}
+
+	planetPath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "planets", name)
+	if _, err := os.Stat(planetPath); os.IsNotExist(err) {
+		return PlanetType{}, "", fmt.Errorf("❌ Planet '%s' does not exist in project '%s'", name, dir)
+	}
+
+	planet, exists := a.planetInstanceType(dir, name)
+	if !exists {
+		return PlanetType{}, "", fmt.Errorf("❌ Unknown type for planet '%s'. Must be one of: %s", name, strings.Join(a.planetTypeNames(), ", "))
+	}
+	return planet, planetPath, nil
+}
+
+// listPlanetInstances returns the planet directories of a project, ordered by the registry
+// order of their type and then by name
+func (a *App) listPlanetInstances(dir string) []string {
+	entries, err := os.ReadDir(filepath.Join(getSolarDir(a.ProjectsDir), dir, "planets"))
+	if err != nil {
+		return []string{}
+	}
+
+	order := make(map[string]int)
+	for i, name := range a.planetTypeNames() {
+		order[name] = i
+	}
+	rank := func(name string) int {
+		if planet, exists := a.planetInstanceType(dir, name); exists {
+			return order[planet.Name]
+		}
+		return len(order)
+	}
+
+	instances := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && planetNameRegex.MatchString(entry.Name()) {
+			instances = append(instances, entry.Name())
+		}
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		if rank(instances[i]) != rank(instances[j]) {
+			return rank(instances[i]) < rank(instances[j])
+		}
+		return instances[i] < instances[j]
+	})
+	return instances
+}
+
+// setPlanetInstanceType records the type of a planet directory in project.json.
+// An empty planetType removes the entry.
+func (a *App) setPlanetInstanceType(dir, name, planetType string) error {
+	projectFilePath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "project.json")
+	unlock := lockFile(projectFilePath)
+	defer unlock()
+
+	projectData, err := readProjectJSONUnlocked(projectFilePath)
+	if err != nil {
+		return err
+	}
+
+	if planetType == "" {
+		delete(projectData.Planets, name)
+	} else {
+		if projectData.Planets == nil {
+			projectData.Planets = make(map[string]string)
+		}
+		projectData.Planets[name] = planetType
+	}
+	return writeProjectJSONUnlocked(projectFilePath, projectData)
+}
+
+// planetTemplateValues are the variables a planet template can use
+func (a *App) planetTemplateValues(dir, name string) map[string]string {
+	projectRoot := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	projectData, _ := readProjectJSON(filepath.Join(projectRoot, "project.json"))
+
+	envFilePath := filepath.Join(projectRoot, dir+"-star", ".env")
+	return map[string]string{
+		"project_dir":  dir,
+		"project_name": projectData.Name,
+		"planet_name":  name,
+		"server_port":  readEnvValue(envFilePath, "PORT", defaultServerPort(dir)),
+		"port":         readEnvValue(envFilePath, planetPortKey(name), ""),
+	}
+}
+
+func validateURLPattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("❌ Invalid URL pattern: %v", err)
+	}
+	if re.NumSubexp() < 1 {
+		return fmt.Errorf("❌ URL pattern must capture the URL in a group")
+	}
+	return nil
+}
+
+// planetURLMatcher returns a function that finds the dev server URL in a planet's output
+func planetURLMatcher(planet PlanetType) func(string) string {
+	if planet.URLPattern == "" {
+		return extractLocalURL
+	}
+
+	re, err := regexp.Compile(planet.URLPattern)
+	if err != nil {
+		fmt.Println("⚠️ Invalid URL pattern for planet type", planet.Name, ":", err)
+		return extractLocalURL
+	}
+	return func(logs string) string {
+		if matches := re.FindStringSubmatch(logs); len(matches) > 1 {
+			return matches[1]
+		}
+		return ""
+	}
+}
+
+// mentionsPath reports whether a ps line references path itself or a file under it, so
+// "planets/web" doesn't also match "planets/web-landing"
+func mentionsPath(line, path string) bool {
+	return strings.Contains(line, path+string(filepath.Separator)) ||
+		strings.Contains(line, path+" ") ||
+		strings.HasSuffix(line, path)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PlanetType describes a kind of planet Genesis can scaffold and run
+type PlanetType struct {
+	Name         string `json:"name"`         // e.g. "web", "admin", "docs"
+	Description  string `json:"description"`  // Shown in the planet picker
+	Template     string `json:"template"`     // Template name, or an inline git URL/path/tarball with optional "#ref"
+	DevCommand   string `json:"devCommand"`   // e.g. "npm run dev"
+	PortFlag     string `json:"portFlag"`     // Appended with the planet's port, e.g. "-- --port"
+	BuildCommand string `json:"buildCommand"` // e.g. "npm run build"
+	URLPattern   string `json:"urlPattern"`   // Regex whose first group is the URL the dev server prints
+	BuiltIn      bool   `json:"builtIn"`      // Shipped with Genesis; saving one with this name overrides it
+}
+
+// GetPlanetTypes returns the built-in planet types followed by the ones from planets.json.
+// Entries in planets.json with a built-in name replace the built-in one.
+func (a *App) GetPlanetTypes() []PlanetType {
+	return a.loadPlanetRegistry()
+}
+
+// SavePlanetType adds or replaces a planet type in planets.json
+func (a *App) SavePlanetType(planet PlanetType) error {
+	if !planetNameRegex.MatchString(planet.Name) {
+		return fmt.Errorf("❌ Invalid planet type name: %s. Use lowercase letters, digits and dashes", planet.Name)
+	}
+	if planet.Template == "" {
+		return fmt.Errorf("❌ Planet type '%s' needs a template", planet.Name)
+	}
+	if planet.DevCommand == "" {
+		return fmt.Errorf("❌ Planet type '%s' needs a dev command", planet.Name)
+	}
+	if err := validateURLPattern(planet.URLPattern); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	custom := a.readPlanetConfig()
+	planet.BuiltIn = false
+
+	replaced := false
+	for i, existing := range custom {
+		if existing.Name == planet.Name {
+			custom[i] = planet
+			replaced = true
+		}
+	}
+	if !replaced {
+		custom = append(custom, planet)
+	}
+
+	if err := a.writePlanetConfig(custom); err != nil {
+		return fmt.Errorf("❌ Failed to write planets.json: %v", err)
+	}
+
+	fmt.Println("✅ Saved planet type:", planet.Name)
+	return nil
+}
+
+// DeletePlanetType removes a planet type from planets.json. Deleting an override of a
+// built-in type restores the built-in one.
+func (a *App) DeletePlanetType(name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	custom := a.readPlanetConfig()
+	kept := []PlanetType{}
+	for _, planet := range custom {
+		if planet.Name != name {
+			kept = append(kept, planet)
+		}
+	}
+	if len(kept) == len(custom) {
+		return fmt.Errorf("❌ Planet type '%s' is not defined in planets.json", name)
+	}
+
+	if err := a.writePlanetConfig(kept); err != nil {
+		return fmt.Errorf("❌ Failed to write planets.json: %v", err)
+	}
+
+	fmt.Println("✅ Deleted planet type:", name)
+	return nil
+}
+
+// AddPlanet scaffolds a planet of the given type under planets/<name>. An empty name uses
+// the type name, so several planets of one type can live side by side (e.g. "web", "web-landing").
+func (a *App) AddPlanet(dir, planetType, name string) error {
+	if name == "" {
+		name = planetType
+	}
+	if !planetNameRegex.MatchString(name) {
+		return fmt.Errorf("❌ Invalid planet name: %s. Use lowercase letters, digits and dashes", name)
+	}
+
+	planet, exists := a.findPlanetType(planetType)
+	if !exists {
+		return fmt.Errorf("❌ Invalid planet type: %s. Must be one of: %s", planetType, strings.Join(a.planetTypeNames(), ", "))
+	}
+
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	planetsPath := filepath.Join(projectPath, "planets")
+	planetDestPath := filepath.Join(planetsPath, name)
+
+	// Ensure the planets directory exists
+	if err := ensureDir(planetsPath); err != nil {
+		return fmt.Errorf("❌ Failed to create planets directory: %v", err)
+	}
+
+	// Check if the planet already exists
+	if _, err := os.Stat(planetDestPath); err == nil {
+		return fmt.Errorf("❌ Planet '%s' already exists in project '%s'", name, dir)
+	}
+
+	// Install the planet template
+	if err := a.installTemplate(planet.Template, planetDestPath, a.planetTemplateValues(dir, name)); err != nil {
+		return fmt.Errorf("❌ Failed to install planet template: %v", err)
+	}
+
+	if err := a.setPlanetInstanceType(dir, name, planet.Name); err != nil {
+		fmt.Println("⚠️ Could not record planet type in project.json:", err)
+	}
+
+	fmt.Println("✅ Successfully added", name, "planet (", planet.Name, ") to project:", dir)
+	return nil
+}
+
+// BuildPlanet runs the build command of a planet's type and returns its output
+func (a *App) BuildPlanet(dir, name string) (string, error) {
+	planet, planetPath, err := a.resolvePlanet(dir, name)
+	if err != nil {
+		return "", err
+	}
+	if planet.BuildCommand == "" {
+		return "", fmt.Errorf("❌ Planet type '%s' has no build command", planet.Name)
+	}
+
+	args := strings.Fields(planet.BuildCommand)
+	fmt.Println("🔨 Building", name, "planet for project", dir, ":", planet.BuildCommand)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = planetPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("❌ Failed to build planet '%s': %v", name, err)
+	}
+
+	fmt.Println("✅ Built", name, "planet for project", dir)
+	return string(output), nil
+}
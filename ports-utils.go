@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Every project gets a block of portBlockSize consecutive ports starting at a base derived
+// from its directory name, so two solar systems don't fight over 8080/5432/5173.
+const (
+	portRangeStart   = 20000
+	portBlockCount   = 1000
+	portBlockSize    = 10
+	serverPortOffset = 0
+	dbPortOffset     = 1
+	planetPortOffset = 2 // One port per planet follows
+)
+
+// projectPortBase returns the preferred first port of a project's block
+func projectPortBase(dir string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(dir))
+	return portRangeStart + int(hash.Sum32()%portBlockCount)*portBlockSize
+}
+
+// allocatePortBlock finds the first block, starting at the project's preferred one, whose ports
+// are all free and not claimed by another project
+func allocatePortBlock(dir string, claimed map[int]bool) (int, error) {
+	preferred := (projectPortBase(dir) - portRangeStart) / portBlockSize
+
+	for i := 0; i < portBlockCount; i++ {
+		base := portRangeStart + ((preferred+i)%portBlockCount)*portBlockSize
+		if blockAvailable(base, claimed) {
+			return base, nil
+		}
+	}
+	return 0, fmt.Errorf("❌ No free port block found between %d and %d", portRangeStart, portRangeStart+portBlockCount*portBlockSize)
+}
+
+func blockAvailable(base int, claimed map[int]bool) bool {
+	for port := base; port < base+portBlockSize; port++ {
+		if claimed[port] || !checkPort(strconv.Itoa(port)).Free {
+			return false
+		}
+	}
+	return true
+}
+
+// usedProjectPorts collects every *PORT value persisted by the other projects
+func (a *App) usedProjectPorts(excludeDir string) map[int]bool {
+	claimed := make(map[int]bool)
+
+	entries, err := os.ReadDir(getSolarDir(a.ProjectsDir))
+	if err != nil {
+		return claimed
+	}
+
+	for _, entry := range entries {
+		if !isDirOrLink(filepath.Join(getSolarDir(a.ProjectsDir), entry.Name())) || entry.Name() == excludeDir {
+			continue
+		}
+
+		env, err := parseEnvFile(filepath.Join(getSolarDir(a.ProjectsDir), entry.Name(), entry.Name()+"-star", ".env"))
+		if err != nil {
+			continue
+		}
+
+		for _, key := range env.keys() {
+			if !strings.HasSuffix(key, "PORT") {
+				continue
+			}
+			value, _ := env.get(key)
+			if port, err := strconv.Atoi(value); err == nil {
+				claimed[port] = true
+			}
+		}
+	}
+	return claimed
+}
+
+// checkPort tries to bind the port and, when that fails, looks up the listening process
+func checkPort(port string) PortStatus {
+	status := PortStatus{Port: port}
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err == nil {
+		listener.Close()
+		status.Free = true
+		return status
+	}
+
+	status.PID, status.Process = findPortOwner(port)
+	return status
+}
+
+// findPortOwner returns the PID and command of the process listening on port, if it can be found
+func findPortOwner(port string) (int, string) {
+	if runtime.GOOS == "windows" {
+		return findPortOwnerWindows(port)
+	}
+
+	// -F pc prints "p<pid>" and "c<command>" lines
+	output, err := exec.Command("lsof", "-nP", "-iTCP:"+port, "-sTCP:LISTEN", "-Fpc").Output()
+	if err != nil {
+		return 0, ""
+	}
+
+	pid, command := 0, ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "p") && pid == 0 {
+			pid, _ = strconv.Atoi(strings.TrimPrefix(line, "p"))
+		}
+		if strings.HasPrefix(line, "c") && command == "" {
+			command = strings.TrimPrefix(line, "c")
+		}
+	}
+	return pid, command
+}
+
+func findPortOwnerWindows(port string) (int, string) {
+	output, err := exec.Command("netstat", "-ano", "-p", "tcp").Output()
+	if err != nil {
+		return 0, ""
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[3] != "LISTENING" || !strings.HasSuffix(fields[1], ":"+port) {
+			continue
+		}
+		pid, _ := strconv.Atoi(fields[4])
+		return pid, ""
+	}
+	return 0, ""
+}
+
+// Matches the host and port of "localhost:5432" / "127.0.0.1:5432" in a DSN
+var dsnPortRegex = regexp.MustCompile(`((?:localhost|127\.0\.0\.1)):(\d+)`)
+
+// replaceDSNPort points a DSN that targets the local machine at a new port
+func replaceDSNPort(dsn, port string) string {
+	return dsnPortRegex.ReplaceAllString(dsn, "${1}:"+port)
+}
+
+// Matches published database ports like `- "5432:5432"` or `- 3306:3306`
+var composeDBPortRegex = regexp.MustCompile(`(?m)^([ \t]*-[ \t]*["']?)(\d+):(5432|3306)(["']?[ \t]*)$`)
+
+// useComposeDBPortVariable rewrites the published database port in docker-compose.yaml to
+// ${DB_PORT:-<original>}, which Compose resolves from the .env next to the compose file
+func useComposeDBPortVariable(composeFile string) error {
+	data, err := os.ReadFile(composeFile)
+	if err != nil {
+		return err
+	}
+
+	if !composeDBPortRegex.Match(data) {
+		if strings.Contains(string(data), "${DB_PORT") {
+			return nil
+		}
+		return fmt.Errorf("no published database port found")
+	}
+
+	updated := composeDBPortRegex.ReplaceAllString(string(data), `${1}$${DB_PORT:-${2}}:${3}${4}`)
+	return os.WriteFile(composeFile, []byte(updated), 0644)
+}
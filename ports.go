@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PortStatus reports whether a port is free and, if not, which process holds it
+type PortStatus struct {
+	Port    string `json:"port"`
+	Free    bool   `json:"free"`
+	PID     int    `json:"pid"`
+	Process string `json:"process"`
+}
+
+// ProjectPorts are the host ports a solar system uses, as persisted in its -star/.env
+type ProjectPorts struct {
+	Server  string            `json:"server"`  // PORT
+	DB      string            `json:"db"`      // DB_PORT
+	Planets map[string]string `json:"planets"` // <PLANET>_PORT, e.g. WEB_PORT
+}
+
+// CheckPort reports whether a TCP port is free on this machine
+func (a *App) CheckPort(port string) (PortStatus, error) {
+	if _, err := parsePort(port); err != nil {
+		return PortStatus{}, err
+	}
+	return checkPort(port), nil
+}
+
+// GetProjectPorts returns the ports configured for a project, falling back to its allocated range
+func (a *App) GetProjectPorts(dir string) ProjectPorts {
+	envFilePath := filepath.Join(getSolarDir(a.ProjectsDir), dir, dir+"-star", ".env")
+	base := projectPortBase(dir)
+
+	ports := ProjectPorts{
+		Server:  readEnvValue(envFilePath, "PORT", strconv.Itoa(base+serverPortOffset)),
+		DB:      readEnvValue(envFilePath, "DB_PORT", strconv.Itoa(base+dbPortOffset)),
+		Planets: map[string]string{},
+	}
+	for i, planet := range a.portedPlanets(dir) {
+		ports.Planets[planet] = readEnvValue(envFilePath, planetPortKey(planet), strconv.Itoa(base+planetPortOffset+i))
+	}
+	return ports
+}
+
+// AssignProjectPorts allocates a free block of ports unique to this project and persists it in
+// -star/.env. docker-compose.yaml and the DSN are updated to use DB_PORT.
+func (a *App) AssignProjectPorts(dir string) (ProjectPorts, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir, dir+"-star")
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return ProjectPorts{}, fmt.Errorf("❌ Project directory does not exist: %s", projectPath)
+	}
+
+	base, err := allocatePortBlock(dir, a.usedProjectPorts(dir))
+	if err != nil {
+		return ProjectPorts{}, err
+	}
+
+	ports := ProjectPorts{
+		Server:  strconv.Itoa(base + serverPortOffset),
+		DB:      strconv.Itoa(base + dbPortOffset),
+		Planets: map[string]string{},
+	}
+	values := map[string]string{
+		"PORT":    ports.Server,
+		"DB_PORT": ports.DB,
+	}
+	for i, planet := range a.portedPlanets(dir) {
+		ports.Planets[planet] = strconv.Itoa(base + planetPortOffset + i)
+		values[planetPortKey(planet)] = ports.Planets[planet]
+	}
+
+	envFilePath := filepath.Join(projectPath, ".env")
+	if dsn := readEnvValue(envFilePath, "DSN", ""); dsn != "" {
+		values["DSN"] = replaceDSNPort(dsn, ports.DB)
+	}
+
+	if err := writeEnvValues(envFilePath, values); err != nil {
+		return ProjectPorts{}, fmt.Errorf("❌ Failed to write .env file: %v", err)
+	}
+
+	if err := useComposeDBPortVariable(filepath.Join(projectPath, "docker-compose.yaml")); err != nil {
+		fmt.Println("⚠️ Could not update docker-compose.yaml to use DB_PORT:", err)
+	}
+
+	fmt.Println("✅ Assigned ports for project", dir, ":", ports)
+	return ports, nil
+}
+
+// portedPlanets returns the planets that get a port from the project's block, in offset order.
+// Projects without planets still reserve web, mobile and desktop.
+func (a *App) portedPlanets(dir string) []string {
+	planets := a.listPlanetInstances(dir)
+	if len(planets) == 0 {
+		planets = []string{"web", "mobile", "desktop"}
+	}
+	if limit := portBlockSize - planetPortOffset; len(planets) > limit {
+		fmt.Println("⚠️ Only the first", limit, "planets of", dir, "get an assigned port")
+		planets = planets[:limit]
+	}
+	return planets
+}
+
+// ensurePortFree returns a descriptive error when something is already listening on port
+func ensurePortFree(port, purpose string) error {
+	status := checkPort(port)
+	if status.Free {
+		return nil
+	}
+
+	owner := "an unknown process"
+	if status.PID > 0 {
+		owner = fmt.Sprintf("%s (PID %d)", status.Process, status.PID)
+	}
+	return fmt.Errorf("❌ Port %s for %s is already in use by %s. Free it or run AssignProjectPorts", port, purpose, owner)
+}
+
+// defaultServerPort is the server port of a project that hasn't set PORT yet
+func defaultServerPort(dir string) string {
+	return strconv.Itoa(projectPortBase(dir) + serverPortOffset)
+}
+
+// planetPortKey is the .env key of a planet's port, e.g. WEB_PORT or WEB_LANDING_PORT
+func planetPortKey(planet string) string {
+	return strings.ToUpper(strings.ReplaceAll(planet, "-", "_")) + "_PORT"
+}
+
+func parsePort(port string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(port))
+	if err != nil || n < 1 || n > 65535 {
+		return 0, fmt.Errorf("❌ Invalid port: %s", port)
+	}
+	return n, nil
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// envLine is one line of a .env file. Lines that aren't touched are written back verbatim.
+type envLine struct {
+	raw     string
+	key     string // Empty for comments and blank lines
+	value   string
+	quote   string // `"`, `'` or "" as found in the file
+	export  bool   // Line started with "export "
+	comment string // Inline comment after an unquoted value, without the "#"
+}
+
+// envFile keeps a parsed .env file in order so it can be edited without losing comments
+type envFile struct {
+	lines []*envLine
+}
+
+var envKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+func parseEnvFile(path string) (*envFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseEnv(string(data)), nil
+}
+
+func parseEnv(content string) *envFile {
+	file := &envFile{}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if content == "" {
+		return file
+	}
+
+	for _, raw := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		file.lines = append(file.lines, parseEnvLine(raw))
+	}
+	return file
+}
+
+func parseEnvLine(raw string) *envLine {
+	line := &envLine{raw: raw}
+
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return line
+	}
+
+	if strings.HasPrefix(trimmed, "export ") {
+		line.export = true
+		trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "export "))
+	}
+
+	key, value, found := strings.Cut(trimmed, "=")
+	key = strings.TrimSpace(key)
+	if !found || !envKeyRegex.MatchString(key) {
+		return line
+	}
+
+	line.key = key
+	value = strings.TrimSpace(value)
+
+	if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+		quote := value[:1]
+		if end := closingQuote(value[1:], quote); end >= 0 {
+			line.quote = quote
+			line.value = value[1 : end+1]
+			if quote == `"` {
+				line.value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(line.value)
+			}
+			rest := strings.TrimSpace(value[end+2:])
+			line.comment = strings.TrimSpace(strings.TrimPrefix(rest, "#"))
+			return line
+		}
+	}
+
+	if index := strings.Index(value, " #"); index >= 0 {
+		line.comment = strings.TrimSpace(value[index+2:])
+		value = strings.TrimSpace(value[:index])
+	}
+	line.value = value
+	return line
+}
+
+// closingQuote returns the index of the quote that ends a quoted value. Double-quoted values may
+// contain escaped quotes (\"), which don't end them.
+func closingQuote(value, quote string) int {
+	for i := 0; i < len(value); i++ {
+		if quote == `"` && value[i] == '\\' {
+			i++ // Skip the escaped character
+			continue
+		}
+		if value[i] == quote[0] {
+			return i
+		}
+	}
+	return -1
+}
+
+// render rebuilds a line after its value changed, keeping quote style and inline comment
+func (l *envLine) render() string {
+	value := l.value
+	quote := l.quote
+	if quote == "" && strings.ContainsAny(value, " #\"'\n") {
+		quote = `"`
+	}
+
+	switch quote {
+	case `"`:
+		value = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+	case `'`:
+		value = `'` + value + `'`
+	}
+
+	result := l.key + "=" + value
+	if l.export {
+		result = "export " + result
+	}
+	if l.comment != "" {
+		result += " # " + l.comment
+	}
+	return result
+}
+
+func (f *envFile) find(key string) *envLine {
+	for _, line := range f.lines {
+		if line.key == key {
+			return line
+		}
+	}
+	return nil
+}
+
+func (f *envFile) get(key string) (string, bool) {
+	if line := f.find(key); line != nil {
+		return line.value, true
+	}
+	return "", false
+}
+
+// set updates a key in place or appends it at the end
+func (f *envFile) set(key, value string) {
+	if line := f.find(key); line != nil {
+		line.value = value
+		line.raw = line.render()
+		return
+	}
+
+	line := &envLine{key: key, value: value}
+	line.raw = line.render()
+	f.lines = append(f.lines, line)
+}
+
+// delete removes a key together with the comment block directly above it
+func (f *envFile) delete(key string) bool {
+	for i, line := range f.lines {
+		if line.key != key {
+			continue
+		}
+
+		start := i
+		for start > 0 && isEnvCommentLine(f.lines[start-1]) {
+			start--
+		}
+		f.lines = append(f.lines[:start], f.lines[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// commentAbove returns the comment lines directly above a key, without the "#"
+func (f *envFile) commentAbove(key string) []string {
+	for i, line := range f.lines {
+		if line.key != key {
+			continue
+		}
+
+		var comments []string
+		for j := i - 1; j >= 0 && isEnvCommentLine(f.lines[j]); j-- {
+			text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(f.lines[j].raw), "#"))
+			comments = append([]string{text}, comments...)
+		}
+		return comments
+	}
+	return nil
+}
+
+func (f *envFile) keys() []string {
+	var keys []string
+	for _, line := range f.lines {
+		if line.key != "" {
+			keys = append(keys, line.key)
+		}
+	}
+	return keys
+}
+
+func (f *envFile) String() string {
+	if len(f.lines) == 0 {
+		return ""
+	}
+
+	raws := make([]string, len(f.lines))
+	for i, line := range f.lines {
+		raws[i] = line.raw
+	}
+	return strings.Join(raws, "\n") + "\n"
+}
+
+func (f *envFile) write(path string) error {
+	return os.WriteFile(path, []byte(f.String()), 0644)
+}
+
+func isEnvCommentLine(line *envLine) bool {
+	return line.key == "" && strings.HasPrefix(strings.TrimSpace(line.raw), "#")
+}
+
+// envSchemaEntry is what .env.example declares about a key through annotations in the comment
+// above it, e.g. "# @required @type=port @secret Port the API listens on"
+type envSchemaEntry struct {
+	Key         string
+	Example     string
+	Required    bool
+	Secret      bool
+	Type        string
+	Description string
+	Order       int
+}
+
+func parseEnvSchema(example *envFile) map[string]envSchemaEntry {
+	schema := make(map[string]envSchemaEntry)
+
+	for order, key := range example.keys() {
+		value, _ := example.get(key)
+		entry := envSchemaEntry{Key: key, Example: value, Type: "string", Secret: looksSecret(key), Order: order}
+
+		var description []string
+		for _, comment := range example.commentAbove(key) {
+			for _, word := range strings.Fields(comment) {
+				switch {
+				case word == "@required":
+					entry.Required = true
+				case word == "@secret":
+					entry.Secret = true
+				case word == "@public":
+					entry.Secret = false
+				case strings.HasPrefix(word, "@type="):
+					entry.Type = strings.TrimPrefix(word, "@type=")
+				default:
+					description = append(description, word)
+				}
+			}
+		}
+		entry.Description = strings.Join(description, " ")
+		schema[key] = entry
+	}
+
+	return schema
+}
+
+// sortedSchemaKeys returns the schema keys in .env.example order
+func sortedSchemaKeys(schema map[string]envSchemaEntry) []string {
+	keys := make([]string, 0, len(schema))
+	for key := range schema {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return schema[keys[i]].Order < schema[keys[j]].Order
+	})
+	return keys
+}
+
+// looksSecret flags keys that commonly hold credentials even without an @secret annotation
+func looksSecret(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, hint := range []string{"SECRET", "PASSWORD", "TOKEN", "API_KEY", "PRIVATE", "DSN"} {
+		if strings.Contains(upper, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEnvValue checks a value against a schema type
+func validateEnvValue(value, valueType string) error {
+	switch valueType {
+	case "", "string":
+		return nil
+	case "int":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("must be a number")
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+	case "port":
+		if _, err := parsePort(value); err != nil {
+			return fmt.Errorf("must be a port between 1 and 65535")
+		}
+	case "url":
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("must be an absolute URL")
+		}
+	default:
+		return fmt.Errorf("has unknown type %q in .env.example", valueType)
+	}
+	return nil
+}
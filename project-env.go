@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const maskedEnvValue = "••••••••"
+
+// EnvEntry is one key of a project's -star/.env, merged with what .env.example says about it
+type EnvEntry struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"` // Masked when Secret is true
+	Comment     string `json:"comment"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Secret      bool   `json:"secret"`
+	Missing     bool   `json:"missing"` // Declared in .env.example but not set in .env
+}
+
+// EnvIssue is a .env.example schema violation
+type EnvIssue struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// ProjectEnv is the editable view of a project's .env
+type ProjectEnv struct {
+	Entries []EnvEntry `json:"entries"`
+	Issues  []EnvIssue `json:"issues"`
+}
+
+// GetProjectEnv returns every key of -star/.env in file order, followed by keys that only
+// .env.example declares. Secret values are masked; use RevealProjectEnv to read one.
+func (a *App) GetProjectEnv(dir string) (ProjectEnv, error) {
+	env, schema, err := a.loadProjectEnv(dir)
+	if err != nil {
+		return ProjectEnv{}, err
+	}
+
+	result := ProjectEnv{Entries: []EnvEntry{}, Issues: validateEnv(env, schema)}
+	for _, key := range env.keys() {
+		value, _ := env.get(key)
+		comments := env.commentAbove(key)
+
+		entry := EnvEntry{Key: key, Value: value, Type: "string", Secret: looksSecret(key)}
+		if declared, exists := schema[key]; exists {
+			entry.Description = declared.Description
+			entry.Type = declared.Type
+			entry.Required = declared.Required
+			entry.Secret = declared.Secret
+		}
+
+		// A "# @secret" comment in .env itself also marks the value secret
+		var commentText []string
+		for _, comment := range comments {
+			if strings.Contains(comment, "@secret") {
+				entry.Secret = true
+				comment = strings.TrimSpace(strings.ReplaceAll(comment, "@secret", ""))
+			}
+			if comment != "" {
+				commentText = append(commentText, comment)
+			}
+		}
+		entry.Comment = strings.Join(commentText, "\n")
+
+		if entry.Secret && entry.Value != "" {
+			entry.Value = maskedEnvValue
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	for _, key := range sortedSchemaKeys(schema) {
+		if _, exists := env.get(key); exists {
+			continue
+		}
+		declared := schema[key]
+		result.Entries = append(result.Entries, EnvEntry{
+			Key:         key,
+			Description: declared.Description,
+			Type:        declared.Type,
+			Required:    declared.Required,
+			Secret:      declared.Secret,
+			Missing:     true,
+		})
+	}
+
+	return result, nil
+}
+
+// RevealProjectEnv returns the unmasked value of a key
+func (a *App) RevealProjectEnv(dir, key string) (string, error) {
+	env, _, err := a.loadProjectEnv(dir)
+	if err != nil {
+		return "", err
+	}
+
+	value, exists := env.get(key)
+	if !exists {
+		return "", fmt.Errorf("⚠️ %s not found in .env file", key)
+	}
+	return value, nil
+}
+
+// SetProjectEnv sets a key, keeping every other line (comments included) where it was
+func (a *App) SetProjectEnv(dir, key, value string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !envKeyRegex.MatchString(key) {
+		return fmt.Errorf("❌ Invalid environment variable name: %s", key)
+	}
+
+	env, schema, err := a.loadProjectEnv(dir)
+	if err != nil {
+		return err
+	}
+
+	// GetProjectEnv masks secrets; saving the mask back must not overwrite them
+	if value == maskedEnvValue {
+		if _, exists := env.get(key); exists {
+			fmt.Println("⚠️ Keeping the stored value of", key, "in .env for project", dir)
+			return nil
+		}
+		return fmt.Errorf("❌ %s cannot be set to the masked placeholder", key)
+	}
+
+	if declared, exists := schema[key]; exists {
+		if err := validateEnvValue(value, declared.Type); err != nil {
+			return fmt.Errorf("❌ %s %v", key, err)
+		}
+	}
+
+	env.set(key, value)
+	if err := env.write(a.projectEnvPath(dir)); err != nil {
+		return fmt.Errorf("❌ Failed to write .env file: %v", err)
+	}
+
+	fmt.Println("✅ Set", key, "in .env for project", dir)
+	return nil
+}
+
+// DeleteProjectEnv removes a key and the comment block directly above it
+func (a *App) DeleteProjectEnv(dir, key string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	env, _, err := a.loadProjectEnv(dir)
+	if err != nil {
+		return err
+	}
+
+	if !env.delete(key) {
+		return fmt.Errorf("⚠️ %s not found in .env file", key)
+	}
+
+	if err := env.write(a.projectEnvPath(dir)); err != nil {
+		return fmt.Errorf("❌ Failed to write .env file: %v", err)
+	}
+
+	fmt.Println("✅ Deleted", key, "from .env for project", dir)
+	return nil
+}
+
+// ValidateProjectEnv checks .env against the required keys and types declared in .env.example
+func (a *App) ValidateProjectEnv(dir string) ([]EnvIssue, error) {
+	env, schema, err := a.loadProjectEnv(dir)
+	if err != nil {
+		return nil, err
+	}
+	return validateEnv(env, schema), nil
+}
+
+func (a *App) projectEnvPath(dir string) string {
+	return filepath.Join(getSolarDir(a.ProjectsDir), dir, dir+"-star", ".env")
+}
+
+// loadProjectEnv parses -star/.env (empty if missing) and the schema from -star/.env.example
+func (a *App) loadProjectEnv(dir string) (*envFile, map[string]envSchemaEntry, error) {
+	if len(dir) == 0 {
+		return nil, nil, fmt.Errorf("❌ Project directory cannot be empty")
+	}
+
+	envPath := a.projectEnvPath(dir)
+	if _, err := os.Stat(filepath.Dir(envPath)); os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("❌ Project directory does not exist: %s", filepath.Dir(envPath))
+	}
+
+	env, err := parseEnvFile(envPath)
+	if os.IsNotExist(err) {
+		env = &envFile{}
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("❌ Failed to read .env file: %v", err)
+	}
+
+	schema := map[string]envSchemaEntry{}
+	if example, err := parseEnvFile(filepath.Join(filepath.Dir(envPath), ".env.example")); err == nil {
+		schema = parseEnvSchema(example)
+	}
+
+	return env, schema, nil
+}
+
+func validateEnv(env *envFile, schema map[string]envSchemaEntry) []EnvIssue {
+	issues := []EnvIssue{}
+
+	for _, key := range sortedSchemaKeys(schema) {
+		declared := schema[key]
+		value, exists := env.get(key)
+
+		if !exists || value == "" {
+			if declared.Required {
+				issues = append(issues, EnvIssue{Key: key, Message: "is required"})
+			}
+			continue
+		}
+
+		if err := validateEnvValue(value, declared.Type); err != nil {
+			issues = append(issues, EnvIssue{Key: key, Message: err.Error()})
+		}
+	}
+
+	return issues
+}
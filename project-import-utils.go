@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// Folder names that usually hold the Go server of a repository, checked before any other folder
+var serverDirCandidates = []string{"server", "api", "backend", "service", "go"}
+
+// detectProjectLayout finds the Go server of a directory and returns the layout and the
+// server folder relative to root
+func detectProjectLayout(root string) (string, string, error) {
+	if fileExists(filepath.Join(root, "go.mod")) {
+		return "server", ".", nil
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", "", fmt.Errorf("❌ Failed to read directory: %v", err)
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") && entry.Name() != "node_modules" {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+
+	for _, name := range dirs {
+		if strings.HasSuffix(name, "-star") && fileExists(filepath.Join(root, name, "go.mod")) {
+			return "solar-system", name, nil
+		}
+	}
+
+	rank := func(name string) int {
+		for i, candidate := range serverDirCandidates {
+			if name == candidate {
+				return i
+			}
+		}
+		return len(serverDirCandidates)
+	}
+	sort.SliceStable(dirs, func(i, j int) bool { return rank(dirs[i]) < rank(dirs[j]) })
+
+	for _, name := range dirs {
+		if fileExists(filepath.Join(root, name, "go.mod")) {
+			return "nested", name, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("❌ No Go server found in %s: expected a go.mod at the root or in a direct subfolder", root)
+}
+
+// importedProjectDir derives a solar system directory name for an imported path
+func importedProjectDir(source, layout, serverRel string) string {
+	if layout == "solar-system" {
+		return strings.TrimSuffix(serverRel, "-star")
+	}
+
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, filepath.Base(source))
+	name = strings.Trim(name, "-")
+
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "project-" + name
+	}
+	return name
+}
+
+// adoptDirectory symlinks or moves source to dest
+func adoptDirectory(source, dest, mode string) error {
+	if mode == "symlink" {
+		if err := os.Symlink(source, dest); err != nil {
+			return fmt.Errorf("❌ Failed to link %s: %v", source, err)
+		}
+		return nil
+	}
+
+	if err := os.Rename(source, dest); err != nil {
+		if errors.Is(err, syscall.EXDEV) {
+			return fmt.Errorf("❌ Cannot move %s to another disk. Import it as a symlink instead", source)
+		}
+		return fmt.Errorf("❌ Failed to move %s: %v", source, err)
+	}
+	return nil
+}
+
+// detectDatabase guesses the database of a server from its compose file and .env
+func detectDatabase(serverPath string) string {
+	var content string
+	for _, name := range []string{"docker-compose.yaml", "docker-compose.yml", "compose.yaml", "compose.yml", ".env"} {
+		if data, err := os.ReadFile(filepath.Join(serverPath, name)); err == nil {
+			content += strings.ToLower(string(data))
+		}
+	}
+
+	switch {
+	case strings.Contains(content, "mysql"), strings.Contains(content, "mariadb"):
+		return "mysql"
+	case strings.Contains(content, "sqlite"):
+		return "sqlite"
+	default:
+		return "postgres"
+	}
+}
+
+// writeImportedProjectFiles writes the Genesis metadata files an imported project is missing
+func writeImportedProjectFiles(projectPath, dir string, o ImportProjectOptions, database string) error {
+	projectFilePath := filepath.Join(projectPath, "project.json")
+	if !fileExists(projectFilePath) {
+		name := o.Name
+		if name == "" {
+			name = dir
+		}
+		projectData := ProjectData{
+			Name:        name,
+			Database:    database,
+			Description: o.Description,
+			ModulePath:  readGoModulePath(filepath.Join(projectPath, dir+"-star")),
+		}
+		if err := writeProjectJSON(projectFilePath, projectData); err != nil {
+			return fmt.Errorf("❌ Failed to write project.json: %v", err)
+		}
+	}
+
+	apexFilePath := filepath.Join(projectPath, "apex.json")
+	if !fileExists(apexFilePath) {
+		apexData := ApexData{
+			Version:    len(apexMigrations),
+			Endpoints:  []Endpoint{},
+			Schemas:    []Schema{},
+			Operations: []Operation{},
+		}
+		if err := writeJSON(apexFilePath, apexData); err != nil {
+			return fmt.Errorf("❌ Failed to write apex.json: %v", err)
+		}
+	}
+
+	sqlEditorFilePath := filepath.Join(projectPath, "sql-editor.json")
+	if !fileExists(sqlEditorFilePath) {
+		if err := writeJSON(sqlEditorFilePath, SQLQueryHistory{Queries: []SQLQuery{}}); err != nil {
+			return fmt.Errorf("❌ Failed to write sql-editor.json: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
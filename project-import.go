@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ImportProjectOptions controls how an existing directory is adopted
+type ImportProjectOptions struct {
+	Dir         string `json:"dir"`  // Name under solar-systems; derived from the path when empty
+	Name        string `json:"name"` // Display name; defaults to Dir
+	Description string `json:"description"`
+	Mode        string `json:"mode"` // "symlink" (default) leaves the directory where it is, "move" moves it
+}
+
+// ImportResult describes what ImportProject detected and did
+type ImportResult struct {
+	Dir      string `json:"dir"`
+	Layout   string `json:"layout"`   // "server" | "solar-system" | "nested"
+	Server   string `json:"server"`   // Go module directory, relative to the imported path
+	Database string `json:"database"` // Detected database, e.g. "postgres"
+	Mode     string `json:"mode"`
+}
+
+// ImportProject adopts an existing Go repository as a solar system. It accepts a bare Go
+// server (go.mod at the root), a Genesis-shaped folder with a <name>-star server, or a repo
+// with the server in a subfolder such as server/ or api/. project.json, an empty apex.json and
+// sql-editor.json are written when missing and git is initialized if needed.
+func (a *App) ImportProject(path string, o ImportProjectOptions) (ImportResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	source, err := filepath.Abs(path)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("❌ Invalid path: %v", err)
+	}
+	if info, err := os.Stat(source); err != nil || !info.IsDir() {
+		return ImportResult{}, fmt.Errorf("❌ Directory does not exist: %s", source)
+	}
+
+	mode := o.Mode
+	if mode == "" {
+		mode = "symlink"
+	}
+	if mode != "symlink" && mode != "move" {
+		return ImportResult{}, fmt.Errorf("❌ Invalid import mode: %s. Must be 'symlink' or 'move'", mode)
+	}
+
+	layout, serverRel, err := detectProjectLayout(source)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	dir := o.Dir
+	if dir == "" {
+		dir = importedProjectDir(source, layout, serverRel)
+	}
+	if !planetNameRegex.MatchString(dir) {
+		return ImportResult{}, fmt.Errorf("❌ Invalid project directory name: %s. Use lowercase letters, digits and dashes", dir)
+	}
+	if layout == "solar-system" && serverRel != dir+"-star" {
+		return ImportResult{}, fmt.Errorf("❌ Project directory must be '%s' to match its %s server", serverRel[:len(serverRel)-len("-star")], serverRel)
+	}
+
+	if err := ensureDir(getSolarDir(a.ProjectsDir)); err != nil {
+		return ImportResult{}, fmt.Errorf("❌ Error ensuring projects directory: %v", err)
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	if _, err := os.Lstat(projectPath); err == nil {
+		return ImportResult{}, fmt.Errorf("❌ Project directory already exists: %s", projectPath)
+	}
+
+	// A bare server becomes the -star of a new solar system folder; otherwise the imported
+	// folder itself is the solar system
+	if layout == "server" {
+		if err := ensureDir(projectPath); err != nil {
+			return ImportResult{}, fmt.Errorf("❌ Failed to create project directory: %v", err)
+		}
+		err = adoptDirectory(source, filepath.Join(projectPath, dir+"-star"), mode)
+	} else {
+		err = adoptDirectory(source, projectPath, mode)
+	}
+	if err != nil {
+		if layout == "server" {
+			os.RemoveAll(projectPath)
+		}
+		return ImportResult{}, err
+	}
+
+	if layout == "nested" {
+		// Genesis expects <dir>/<dir>-star, so point it at the detected server folder
+		if err := os.Symlink(serverRel, filepath.Join(projectPath, dir+"-star")); err != nil {
+			return ImportResult{}, fmt.Errorf("❌ Failed to link %s-star to %s: %v", dir, serverRel, err)
+		}
+	}
+
+	database := detectDatabase(filepath.Join(projectPath, dir+"-star"))
+	if err := writeImportedProjectFiles(projectPath, dir, o, database); err != nil {
+		return ImportResult{}, err
+	}
+
+	// A moved-in server with its own history stays out of the solar system's repository
+	if layout == "server" {
+		if _, err := os.Stat(filepath.Join(projectPath, dir+"-star", ".git")); err == nil {
+			os.WriteFile(filepath.Join(projectPath, ".gitignore"), []byte("/"+dir+"-star\n"), 0644)
+		}
+	}
+
+	// Existing repositories keep their history and the new files stay uncommitted for review
+	if _, err := os.Stat(filepath.Join(projectPath, ".git")); os.IsNotExist(err) {
+		if err := a.InitGitRepo(dir); err != nil {
+			fmt.Println("⚠️ Failed to initialize git repository:", err)
+		} else if err := a.GitCommit(dir, "Import project into Genesis"); err != nil {
+			fmt.Println("⚠️ Failed to commit imported project:", err)
+		}
+	}
+
+	fmt.Println("✅ Project imported:", source, "→", projectPath, "(", layout, mode, ")")
+	return ImportResult{Dir: dir, Layout: layout, Server: serverRel, Database: database, Mode: mode}, nil
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Matches "<dir>-20240131-235959.tar.gz"
+var projectArchiveNameRegex = regexp.MustCompile(`^(.+)-(\d{8}-\d{6})\.tar\.gz$`)
+
+func (a *App) projectArchiveDir() string {
+	return filepath.Join(a.ProjectsDir, "genesis", "archive")
+}
+
+func parseArchiveName(name string) (string, time.Time, bool) {
+	match := projectArchiveNameRegex.FindStringSubmatch(name)
+	if match == nil {
+		return "", time.Time{}, false
+	}
+	archivedAt, err := time.ParseInLocation("20060102-150405", match[2], time.Local)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return match[1], archivedAt, true
+}
+
+// validateProjectDir rejects names that can't be a single folder under solar-systems
+func validateProjectDir(dir string) error {
+	if len(strings.TrimSpace(dir)) == 0 {
+		return fmt.Errorf("❌ Project directory cannot be empty")
+	}
+	if dir == "." || dir == ".." || strings.ContainsAny(dir, `/\`) {
+		return fmt.Errorf("❌ Invalid project directory: %s", dir)
+	}
+	return nil
+}
+
+// checkProjectTarget validates a source project and a free target name for rename/duplicate
+func (a *App) checkProjectTarget(dir, newDir string) (string, string, error) {
+	if err := validateProjectDir(dir); err != nil {
+		return "", "", err
+	}
+	if err := validateProjectDir(newDir); err != nil {
+		return "", "", err
+	}
+
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return "", "", fmt.Errorf("❌ Project directory does not exist: %s", projectPath)
+	}
+
+	newProjectPath := filepath.Join(getSolarDir(a.ProjectsDir), newDir)
+	if _, err := os.Lstat(newProjectPath); err == nil {
+		return "", "", fmt.Errorf("❌ Project directory already exists: %s", newProjectPath)
+	}
+	return projectPath, newProjectPath, nil
+}
+
+// projectBusy reports whether Genesis is running or watching anything for a project
+func (a *App) projectBusy(dir string) bool {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	if _, exists := a.watchers[dir]; exists {
+		return true
+	}
+	for id, proc := range a.processes {
+		if processDir, _ := processSource(id); processDir == dir && proc.running() {
+			return true
+		}
+	}
+	return false
+}
+
+// forgetProjectState drops in-memory logs and health kept for a project that moved away
+func (a *App) forgetProjectState(dir string) {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	for id := range a.logs {
+		if processDir, _ := processSource(id); processDir == dir {
+			delete(a.logs, id)
+		}
+	}
+	delete(a.health, dir)
+}
+
+// renameProjectServer renames <dir>-star inside a project that now lives at projectPath, and
+// moves a module path derived from the old name along with it
+func renameProjectServer(projectPath, dir, newDir string) error {
+	starPath := filepath.Join(projectPath, newDir+"-star")
+	if err := os.Rename(filepath.Join(projectPath, dir+"-star"), starPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("❌ Failed to rename %s-star: %v", dir, err)
+	}
+
+	modulePath := readGoModulePath(starPath)
+	newModulePath := renamedModulePath(modulePath, dir, newDir)
+	if newModulePath != modulePath {
+		if err := renameGoModule(starPath, newModulePath); err != nil {
+			return fmt.Errorf("❌ Failed to update module path: %v", err)
+		}
+	}
+
+	projectFilePath := filepath.Join(projectPath, "project.json")
+	unlock := lockFile(projectFilePath)
+	defer unlock()
+
+	if projectData, err := readProjectJSONUnlocked(projectFilePath); err == nil && projectData.ModulePath != "" {
+		projectData.ModulePath = renamedModulePath(projectData.ModulePath, dir, newDir)
+		if err := writeProjectJSONUnlocked(projectFilePath, projectData); err != nil {
+			return fmt.Errorf("❌ Failed to write project.json: %v", err)
+		}
+	}
+	return nil
+}
+
+// renamedModulePath swaps the last element of a module path when it was derived from dir
+func renamedModulePath(modulePath, dir, newDir string) string {
+	oldName, newName := defaultModulePath(dir), defaultModulePath(newDir)
+	switch {
+	case modulePath == oldName:
+		return newName
+	case strings.HasSuffix(modulePath, "/"+oldName):
+		return strings.TrimSuffix(modulePath, oldName) + newName
+	default:
+		return modulePath
+	}
+}
+
+// skipProjectCopy leaves out what can be rebuilt: installed packages, Genesis build output and
+// coverage profiles. The rest of .genesis (logs, migration backups, apex history) is kept.
+func skipProjectCopy(rel string, info os.FileInfo) bool {
+	if info.IsDir() {
+		return info.Name() == "node_modules" || rel == filepath.Join(".genesis", "build")
+	}
+	return rel == filepath.Join(".genesis", "coverage.out")
+}
+
+// copyProjectDir copies a project, following the root and any symlink that points outside of
+// it (imported projects) while keeping links within the project as links
+func copyProjectDir(src, dest string) error {
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	return walkProject(root, skipProjectCopy, func(rel, path string, info os.FileInfo, link string) error {
+		target := filepath.Join(dest, rel)
+		switch {
+		case link != "":
+			return os.Symlink(link, target)
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		default:
+			if err := copyFile(path, target); err != nil {
+				return err
+			}
+			return os.Chmod(target, info.Mode().Perm())
+		}
+	})
+}
+
+// writeProjectTarball writes a project to a .tar.gz with every entry under prefix/
+func writeProjectTarball(src, prefix, archivePath string) error {
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = walkProject(root, skipProjectCopy, func(rel, path string, info os.FileInfo, link string) error {
+		return addTarEntry(tarWriter, filepath.Join(prefix, rel), path, info, link)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// addTarEntry writes a file, directory or symlink (link set) to an archive under name
+func addTarEntry(tarWriter *tar.Writer, name, path string, info os.FileInfo, link string) error {
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	if link != "" {
+		header.Typeflag = tar.TypeSymlink
+		header.Size = 0
+	} else if info.IsDir() {
+		header.Name += "/"
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if link != "" || !info.Mode().IsRegular() {
+		return nil
+	}
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	_, err = io.Copy(tarWriter, source)
+	return err
+}
+
+// walkProject calls fn for every entry under root that skip doesn't exclude. link is set for
+// symlinks that stay within root; other symlinks are resolved and walked as their target.
+func walkProject(root string, skip func(rel string, info os.FileInfo) bool, fn func(rel, path string, info os.FileInfo, link string) error) error {
+	var walk func(path, rel string) error
+	walk = func(path, rel string) error {
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			resolved, err := filepath.EvalSymlinks(path)
+			if err != nil {
+				return nil // Dangling link
+			}
+			if resolved == root || strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+				return fn(rel, path, info, link)
+			}
+			path = resolved
+			if info, err = os.Stat(path); err != nil {
+				return err
+			}
+		}
+
+		if rel != "." && skip(rel, info) {
+			return nil
+		}
+		if err := fn(rel, path, info, ""); err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := walk(filepath.Join(path, entry.Name()), filepath.Join(rel, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(root, ".")
+}
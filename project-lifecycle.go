@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchivedProject is a project archive in GENESIS_PATH/genesis/archive
+type ArchivedProject struct {
+	File       string `json:"file"` // Archive file name, passed to RestoreProject
+	Dir        string `json:"dir"`
+	ArchivedAt string `json:"archivedAt"`
+	Size       int64  `json:"size"` // Bytes
+}
+
+// RenameProject renames a solar system and its <dir>-star folder. A module path derived from
+// the old directory name (e.g. "my-app" or "github.com/me/my-app") follows the new name. The
+// changes are left uncommitted so they never get mixed with the user's own work.
+func (a *App) RenameProject(dir, newDir string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	projectPath, newProjectPath, err := a.checkProjectTarget(dir, newDir)
+	if err != nil {
+		return err
+	}
+	if a.projectBusy(dir) {
+		return fmt.Errorf("❌ Stop the server, dev servers and watchers of '%s' before renaming it", dir)
+	}
+
+	a.closeLogArchives(dir)
+	if err := os.Rename(projectPath, newProjectPath); err != nil {
+		return fmt.Errorf("❌ Failed to rename project directory: %v", err)
+	}
+
+	if err := renameProjectServer(newProjectPath, dir, newDir); err != nil {
+		return err
+	}
+	a.forgetProjectState(dir)
+
+	fmt.Println("✅ Project renamed:", dir, "→", newDir)
+	return nil
+}
+
+// DuplicateProject copies a solar system, git history included, to branch an experiment.
+// node_modules, build output and coverage profiles are left out and the copy gets its own port
+// block. Like RenameProject, the renamed server is left uncommitted in the copy.
+func (a *App) DuplicateProject(dir, newDir string) error {
+	a.mu.Lock()
+	projectPath, newProjectPath, err := a.checkProjectTarget(dir, newDir)
+	if err != nil {
+		a.mu.Unlock()
+		return err
+	}
+
+	fmt.Println("📋 Duplicating project", dir, "to", newDir)
+	if err := copyProjectDir(projectPath, newProjectPath); err != nil {
+		os.RemoveAll(newProjectPath)
+		a.mu.Unlock()
+		return fmt.Errorf("❌ Failed to copy project: %v", err)
+	}
+
+	if err := renameProjectServer(newProjectPath, dir, newDir); err != nil {
+		a.mu.Unlock()
+		return err
+	}
+
+	projectFilePath := filepath.Join(newProjectPath, "project.json")
+	unlock := lockFile(projectFilePath)
+	if projectData, err := readProjectJSONUnlocked(projectFilePath); err == nil {
+		projectData.Name += " (copy)"
+		writeProjectJSONUnlocked(projectFilePath, projectData)
+	}
+	unlock()
+	a.mu.Unlock()
+
+	// The copy would otherwise fight the original over the same ports
+	if _, err := os.Stat(filepath.Join(newProjectPath, newDir+"-star", ".env")); err == nil {
+		if _, err := a.AssignProjectPorts(newDir); err != nil {
+			fmt.Println("⚠️ Failed to assign ports to the copy:", err)
+		}
+	}
+
+	fmt.Println("✅ Project duplicated:", dir, "→", newDir)
+	return nil
+}
+
+// ArchiveProject packs a project into GENESIS_PATH/genesis/archive/<dir>-<timestamp>.tar.gz and
+// removes it from solar-systems. Build output and node_modules are left out. Returns the file name.
+func (a *App) ArchiveProject(dir string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(dir) == 0 {
+		return "", fmt.Errorf("❌ Project directory cannot be empty")
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return "", fmt.Errorf("❌ Project directory does not exist: %s", projectPath)
+	}
+	if a.projectBusy(dir) {
+		return "", fmt.Errorf("❌ Stop the server, dev servers and watchers of '%s' before archiving it", dir)
+	}
+
+	archiveDir := a.projectArchiveDir()
+	if err := ensureDir(archiveDir); err != nil {
+		return "", fmt.Errorf("❌ Failed to create archive directory: %v", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.tar.gz", dir, time.Now().Format("20060102-150405"))
+	a.closeLogArchives(dir)
+	if err := writeProjectTarball(projectPath, dir, filepath.Join(archiveDir, name)); err != nil {
+		os.Remove(filepath.Join(archiveDir, name))
+		return "", fmt.Errorf("❌ Failed to archive project: %v", err)
+	}
+
+	// Only the link goes for imported projects; the original directory stays where it was
+	if err := os.RemoveAll(projectPath); err != nil {
+		return name, fmt.Errorf("❌ Project archived to %s but could not be removed: %v", name, err)
+	}
+	a.forgetProjectState(dir)
+
+	fmt.Println("📦 Project archived:", dir, "→", filepath.Join(archiveDir, name))
+	return name, nil
+}
+
+// GetArchivedProjects lists project archives, newest first
+func (a *App) GetArchivedProjects() []ArchivedProject {
+	archives := []ArchivedProject{}
+
+	entries, err := os.ReadDir(a.projectArchiveDir())
+	if err != nil {
+		return archives
+	}
+
+	for _, entry := range entries {
+		dir, archivedAt, ok := parseArchiveName(entry.Name())
+		if entry.IsDir() || !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		archives = append(archives, ArchivedProject{
+			File:       entry.Name(),
+			Dir:        dir,
+			ArchivedAt: archivedAt.Format(time.RFC3339),
+			Size:       info.Size(),
+		})
+	}
+
+	sort.Slice(archives, func(i, j int) bool { return archives[i].ArchivedAt > archives[j].ArchivedAt })
+	return archives
+}
+
+// RestoreProject unpacks an archive back into solar-systems, under newDir when given.
+// The archive is kept until the restore succeeded and is then removed.
+func (a *App) RestoreProject(file, newDir string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	dir, _, ok := parseArchiveName(file)
+	if !ok || strings.ContainsAny(file, `/\`) {
+		return fmt.Errorf("❌ Invalid project archive: %s", file)
+	}
+	archivePath := filepath.Join(a.projectArchiveDir(), file)
+	if _, err := os.Stat(archivePath); os.IsNotExist(err) {
+		return fmt.Errorf("❌ Project archive does not exist: %s", file)
+	}
+
+	if newDir == "" {
+		newDir = dir
+	}
+	if err := validateProjectDir(newDir); err != nil {
+		return err
+	}
+	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), newDir)
+	if err := checkIfProjectExists(projectPath); err != nil {
+		return err
+	}
+
+	if err := extractTarball(archivePath, projectPath); err != nil {
+		os.RemoveAll(projectPath)
+		return fmt.Errorf("❌ Failed to restore project: %v", err)
+	}
+
+	if newDir != dir {
+		if err := renameProjectServer(projectPath, dir, newDir); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Remove(archivePath); err != nil {
+		fmt.Println("⚠️ Failed to remove restored archive:", err)
+	}
+
+	fmt.Println("✅ Project restored:", file, "→", newDir)
+	return nil
+}
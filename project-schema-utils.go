@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// documentMigration upgrades a raw project.json or apex.json by one version. root is the
+// project directory the file belongs to.
+type documentMigration struct {
+	Description string
+	Migrate     func(doc map[string]json.RawMessage, root string) error
+}
+
+// projectMigrations[i] upgrades project.json from version i to i+1. Append only: released
+// versions must never change, and the last index + 1 is the current version.
+var projectMigrations = []documentMigration{
+	{"Add version, timestamps, template and planet list", migrateProjectV1},
+}
+
+// apexMigrations[i] upgrades apex.json from version i to i+1, like projectMigrations
+var apexMigrations = []documentMigration{
+	{"Add version and replace null lists", migrateApexV1},
+	{"Require every field of schemas without a required list", migrateApexV2},
+}
+
+// migrateDocument brings a raw JSON document up to the current version of its migrations.
+// Newer documents are refused rather than rewritten, so an older Genesis can't drop fields
+// it doesn't know about.
+func migrateDocument(path string, data []byte, migrations []documentMigration) ([]byte, bool, error) {
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, false, err
+	}
+
+	version := 0
+	if raw, exists := doc["version"]; exists {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, false, fmt.Errorf("invalid version: %s", raw)
+		}
+	}
+
+	current := len(migrations)
+	if version > current {
+		return nil, false, fmt.Errorf("❌ %s is version %d but this Genesis only supports up to %d. Update Genesis to open it", filepath.Base(path), version, current)
+	}
+	if version == current {
+		return data, false, nil
+	}
+
+	root := filepath.Dir(path)
+	for v := version; v < current; v++ {
+		fmt.Println("⬆️ Migrating", path, "to version", v+1, "-", migrations[v].Description)
+		if err := migrations[v].Migrate(doc, root); err != nil {
+			return nil, false, fmt.Errorf("❌ Failed to migrate %s to version %d: %v", filepath.Base(path), v+1, err)
+		}
+	}
+	doc["version"] = json.RawMessage(fmt.Sprint(current))
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Keep what was there before in .genesis, in case a migration got something wrong. Copies
+	// that live in .genesis already, like the apex.json history, need no backup.
+	if filepath.Base(filepath.Dir(root)) != ".genesis" && filepath.Base(root) != ".genesis" {
+		backup := filepath.Join(getGenesisDir(root), "migrations", fmt.Sprintf("%s.v%d", filepath.Base(path), version))
+		if err := ensureDir(filepath.Dir(backup)); err == nil {
+			os.WriteFile(backup, data, 0644)
+		}
+	}
+	return migrated, true, nil
+}
+
+func migrateProjectV1(doc map[string]json.RawMessage, root string) error {
+	createdAt := time.Now()
+	if info, err := os.Stat(filepath.Join(root, "project.json")); err == nil {
+		createdAt = info.ModTime()
+	}
+	setDefaultField(doc, "createdAt", createdAt.Format(time.RFC3339))
+	setDefaultField(doc, "updatedAt", createdAt.Format(time.RFC3339))
+
+	// Projects created before templates were configurable came from the solar-system template;
+	// imported ones link to their server instead
+	if info, err := os.Lstat(filepath.Join(root, filepath.Base(root)+"-star")); err == nil && info.IsDir() {
+		setDefaultField(doc, "template", "solar-system")
+	}
+
+	// Record the planets that exist, typed by the built-in planet their name starts with
+	planets := map[string]string{}
+	if raw, exists := doc["planets"]; exists {
+		if err := json.Unmarshal(raw, &planets); err != nil {
+			return fmt.Errorf("invalid planets: %v", err)
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Join(root, "planets"))
+	for _, entry := range entries {
+		if _, recorded := planets[entry.Name()]; recorded || !entry.IsDir() {
+			continue
+		}
+		for _, planet := range defaultPlanetTypes {
+			if entry.Name() == planet.Name || strings.HasPrefix(entry.Name(), planet.Name+"-") {
+				planets[entry.Name()] = planet.Name
+				break
+			}
+		}
+	}
+	if len(planets) > 0 {
+		data, err := json.Marshal(planets)
+		if err != nil {
+			return err
+		}
+		doc["planets"] = data
+	}
+	return nil
+}
+
+func migrateApexV1(doc map[string]json.RawMessage, root string) error {
+	for _, key := range []string{"endpoints", "schemas", "operations"} {
+		if raw, exists := doc[key]; !exists || string(raw) == "null" {
+			doc[key] = json.RawMessage("[]")
+		}
+	}
+	return nil
+}
+
+// migrateApexV2 keeps generated code the same once the required list is read: it used to be
+// ignored, so every field was required
+func migrateApexV2(doc map[string]json.RawMessage, root string) error {
+	var schemas []map[string]json.RawMessage
+	if err := json.Unmarshal(doc["schemas"], &schemas); err != nil {
+		return err
+	}
+
+	for _, schema := range schemas {
+		var required []string
+		json.Unmarshal(schema["required"], &required)
+		if len(required) > 0 {
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		json.Unmarshal(schema["fields"], &fields)
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		schema["required"], _ = json.Marshal(names)
+	}
+
+	data, err := json.Marshal(schemas)
+	if err != nil {
+		return err
+	}
+	doc["schemas"] = data
+	return nil
+}
+
+func setDefaultField(doc map[string]json.RawMessage, key string, value interface{}) {
+	if _, exists := doc[key]; exists {
+		return
+	}
+	if data, err := json.Marshal(value); err == nil {
+		doc[key] = data
+	}
+}
+
+// splitUnknownFields returns the top-level fields of data that the struct type of known
+// doesn't declare
+func splitUnknownFields(data []byte, known interface{}) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	knownType := reflect.TypeOf(known)
+	for i := 0; i < knownType.NumField(); i++ {
+		name, _, _ := strings.Cut(knownType.Field(i).Tag.Get("json"), ",")
+		delete(fields, name)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// appendUnknownFields adds preserved fields after the known ones of a marshalled object, in
+// name order so rewrites stay stable
+func appendUnknownFields(data []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	buffer.Write(bytes.TrimSuffix(bytes.TrimSpace(data), []byte("}")))
+	for i, name := range names {
+		if i > 0 || len(bytes.TrimSpace(data)) > 2 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(extra[name])
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// writeProjectJSON writes project.json at the current version and stamps updatedAt
+func writeProjectJSON(filePath string, data ProjectData) error {
+	unlock := lockFile(filePath)
+	defer unlock()
+
+	return writeProjectJSONUnlocked(filePath, data)
+}
+
+// writeProjectJSONUnlocked is writeProjectJSON for callers that already hold the file's lock
+func writeProjectJSONUnlocked(filePath string, data ProjectData) error {
+	data.Version = len(projectMigrations)
+	data.UpdatedAt = time.Now().Format(time.RFC3339)
+	if data.CreatedAt == "" {
+		data.CreatedAt = data.UpdatedAt
+	}
+	_, err := writeJSONUnlocked(filePath, data)
+	return err
+}
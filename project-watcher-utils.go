@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const projectWatchDebounce = 250 * time.Millisecond
+
+// projectWatcher turns file changes under solar-systems into Wails events. It only watches the
+// directories whose direct children matter: solar-systems itself, every project root, its
+// -star folder and its planets folder.
+type projectWatcher struct {
+	app      *App
+	solarDir string
+	watcher  *fsnotify.Watcher
+	stop     chan struct{}
+
+	mu       sync.Mutex
+	projects map[string]bool // Project directories at the last flush
+	pending  map[string]bool // Event names waiting for the debounce to settle
+}
+
+func newProjectWatcher(a *App, solarDir string) (*projectWatcher, error) {
+	if err := ensureDir(solarDir); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(solarDir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	w := &projectWatcher{
+		app:      a,
+		solarDir: solarDir,
+		watcher:  watcher,
+		stop:     make(chan struct{}),
+		projects: make(map[string]bool),
+		pending:  make(map[string]bool),
+	}
+	for _, dir := range w.listProjects() {
+		w.projects[dir] = true
+		w.addProject(dir)
+	}
+	return w, nil
+}
+
+// listProjects returns the directories under solar-systems, symlinked imports included
+func (w *projectWatcher) listProjects() []string {
+	entries, err := os.ReadDir(w.solarDir)
+	if err != nil {
+		return []string{}
+	}
+
+	dirs := []string{}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), ".") && isDirOrLink(filepath.Join(w.solarDir, entry.Name())) {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	return dirs
+}
+
+// addProject watches a project's root, server and planets folders. Missing ones are picked up
+// when they're created.
+func (w *projectWatcher) addProject(dir string) {
+	root := filepath.Join(w.solarDir, dir)
+	for _, path := range []string{root, filepath.Join(root, dir+"-star"), filepath.Join(root, "planets")} {
+		if isDirOrLink(path) {
+			w.watcher.Add(path)
+		}
+	}
+}
+
+func (w *projectWatcher) removeProject(dir string) {
+	root := filepath.Join(w.solarDir, dir)
+	for _, path := range []string{root, filepath.Join(root, dir+"-star"), filepath.Join(root, "planets")} {
+		w.watcher.Remove(path)
+	}
+}
+
+// classify maps a changed path to the event it should trigger, or "" when nobody cares
+func (w *projectWatcher) classify(path string) string {
+	rel, err := filepath.Rel(w.solarDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	dir := parts[0]
+	if strings.HasPrefix(dir, ".") {
+		return ""
+	}
+
+	switch {
+	case len(parts) == 1:
+		return "projects:changed"
+	case len(parts) == 2 && parts[1] == "apex.json":
+		return "project:apex:" + dir
+	case len(parts) == 2 && parts[1] == "project.json":
+		return "project:metadata:" + dir
+	case len(parts) == 2 && (parts[1] == "planets" || parts[1] == dir+"-star"):
+		// Created after the project was: watch it from now on
+		w.watcher.Add(path)
+		if parts[1] == "planets" {
+			return "project:planets:" + dir
+		}
+		return ""
+	case len(parts) == 3 && parts[1] == "planets":
+		return "project:planets:" + dir
+	case len(parts) == 3 && parts[1] == dir+"-star" && (parts[2] == ".env" || parts[2] == ".env.example"):
+		return "project:env:" + dir
+	default:
+		return ""
+	}
+}
+
+func (w *projectWatcher) run() {
+	timer := time.NewTimer(projectWatchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			timer.Stop()
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			name := w.classify(event.Name)
+			if name == "" || event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			w.mu.Lock()
+			w.pending[name] = true
+			w.mu.Unlock()
+			timer.Reset(projectWatchDebounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			fmt.Println("⚠️ Project watcher error:", err)
+		case <-timer.C:
+			w.flush()
+		}
+	}
+}
+
+// flush emits the events collected since the last one. Project names show up on the projects
+// list, so a project.json change is emitted as a list change.
+func (w *projectWatcher) flush() {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = make(map[string]bool)
+	w.mu.Unlock()
+
+	change := ProjectsChange{Added: []string{}, Removed: []string{}}
+	listChanged := pending["projects:changed"]
+	if listChanged {
+		current := make(map[string]bool)
+		for _, dir := range w.listProjects() {
+			current[dir] = true
+			if !w.projects[dir] {
+				change.Added = append(change.Added, dir)
+				w.addProject(dir)
+			}
+		}
+		for dir := range w.projects {
+			if !current[dir] {
+				change.Removed = append(change.Removed, dir)
+				w.removeProject(dir)
+			}
+		}
+		sort.Strings(change.Added)
+		sort.Strings(change.Removed)
+		w.projects = current
+	}
+
+	names := make([]string, 0, len(pending))
+	for name := range pending {
+		// Removing a project deletes everything in it; the list change says it all
+		if name == "projects:changed" || !w.projects[name[strings.LastIndex(name, ":")+1:]] {
+			continue
+		}
+		if strings.HasPrefix(name, "project:metadata:") {
+			listChanged = true
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if w.app.ctx == nil {
+		return
+	}
+	if listChanged {
+		runtime.EventsEmit(w.app.ctx, "projects:changed", change)
+	}
+	for _, name := range names {
+		runtime.EventsEmit(w.app.ctx, name)
+	}
+}
+
+func (w *projectWatcher) close() {
+	close(w.stop)
+	w.watcher.Close()
+}
@@ -0,0 +1,48 @@
+package main
+
+import "fmt"
+
+// ProjectsChange is the payload of the "projects:changed" event. Both lists are empty when only
+// a project's metadata changed.
+type ProjectsChange struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// startProjectWatcher (re)starts watching GENESIS_PATH/genesis/solar-systems. Changes are
+// debounced and emitted as Wails events so every screen can refresh without polling:
+//
+//	projects:changed           a project was added, removed, renamed or its project.json changed (ProjectsChange)
+//	project:apex:<dir>         apex.json changed, e.g. edited in VS Code or pulled
+//	project:env:<dir>          -star/.env or .env.example changed
+//	project:planets:<dir>      a planet was added or removed
+func (a *App) startProjectWatcher() {
+	a.stopProjectWatcher()
+	if a.ProjectsDir == "" {
+		return
+	}
+
+	watcher, err := newProjectWatcher(a, getSolarDir(a.ProjectsDir))
+	if err != nil {
+		fmt.Println("⚠️ Failed to watch projects:", err)
+		return
+	}
+
+	a.procMu.Lock()
+	a.projectWatcher = watcher
+	a.procMu.Unlock()
+
+	go watcher.run()
+	fmt.Println("👀 Watching projects for changes:", watcher.solarDir)
+}
+
+func (a *App) stopProjectWatcher() {
+	a.procMu.Lock()
+	watcher := a.projectWatcher
+	a.projectWatcher = nil
+	a.procMu.Unlock()
+
+	if watcher != nil {
+		watcher.close()
+	}
+}
@@ -6,11 +6,16 @@ import (
 	"io"
 	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
 )
 
+// isDirOrLink reports whether path is a directory or a symlink to one, like imported projects
+func isDirOrLink(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
 func ensureDir(dir string) error {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		fmt.Println("📁 Creating missing directory:", dir)
@@ -39,7 +44,7 @@ func getProjectMetadata(dir string) ([]ProjectInfo, error) {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if isDirOrLink(filepath.Join(dir, entry.Name())) {
 			projectPath := filepath.Join(dir, entry.Name(), "project.json")
 
 			fmt.Println("📖 Checking for project.json in:", projectPath)
@@ -62,19 +67,40 @@ func getProjectMetadata(dir string) ([]ProjectInfo, error) {
 	return projects, nil
 }
 
-// readProjectJSON reads and parses a project.json file
+// readProjectJSON reads and parses a project.json file, migrating it to the current version
 func readProjectJSON(filePath string) (ProjectData, error) {
+	unlock := lockFile(filePath)
+	defer unlock()
+
+	return readProjectJSONUnlocked(filePath)
+}
+
+// readProjectJSONUnlocked is readProjectJSON for callers that hold the file's lock across a
+// read-modify-write cycle
+func readProjectJSONUnlocked(filePath string) (ProjectData, error) {
 	file, err := os.ReadFile(filePath)
 	if err != nil {
 		return ProjectData{}, err
 	}
 
+	file, migrated, err := migrateDocument(filePath, file, projectMigrations)
+	if err != nil {
+		fmt.Println("❌ Error migrating", filePath, ":", err)
+		return ProjectData{}, err
+	}
+
 	var data ProjectData
 	if err := json.Unmarshal(file, &data); err != nil {
 		fmt.Println("❌ Error parsing JSON in", filePath, ":", err)
 		return ProjectData{}, err
 	}
 
+	if migrated {
+		if _, err := writeJSONUnlocked(filePath, data); err != nil {
+			fmt.Println("⚠️ Failed to save migrated", filePath, ":", err)
+		}
+	}
+
 	return data, nil
 }
 
@@ -92,16 +118,13 @@ func getProjectsPath() string {
 	return filepath.Join(homeDir, "Developer")
 }
 
+// writeJSON atomically replaces a JSON file, waiting for anyone else writing it
 func writeJSON(filePath string, data interface{}) error {
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	unlock := lockFile(filePath)
+	defer unlock()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(data)
+	_, err := writeJSONUnlocked(filePath, data)
+	return err
 }
 
 func validateProjectOptions(o NewProjectOptions) error {
@@ -114,6 +137,70 @@ func validateProjectOptions(o NewProjectOptions) error {
 	if len(o.Database) == 0 {
 		return fmt.Errorf("❌ Database name cannot be empty")
 	}
+	if _, err := findDialect(o.Database); err != nil {
+		return err
+	}
+	if o.ModulePath != "" && !modulePathRegex.MatchString(o.ModulePath) {
+		return fmt.Errorf("❌ Invalid Go module path: %s", o.ModulePath)
+	}
+	if o.DBName != "" && defaultDBName(o.DBName) != o.DBName {
+		return fmt.Errorf("❌ Invalid database name: %s. Use lowercase letters, digits and underscores", o.DBName)
+	}
+	if o.Port != "" {
+		if _, err := parsePort(o.Port); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectTemplateValues are the variables every template can use, with defaults filled in
+func projectTemplateValues(o NewProjectOptions) map[string]string {
+	values := map[string]string{
+		"project_dir":  o.Dir,
+		"project_name": o.Name,
+		"description":  o.Description,
+		"database":     o.Database,
+		"module_path":  o.ModulePath,
+		"db_name":      o.DBName,
+		"port":         o.Port,
+	}
+	if values["module_path"] == "" {
+		values["module_path"] = defaultModulePath(o.Dir)
+	}
+	if values["db_name"] == "" {
+		values["db_name"] = defaultDBName(o.Dir)
+	}
+	if values["port"] == "" {
+		values["port"] = defaultServerPort(o.Dir)
+	}
+	return values
+}
+
+// applyProjectOptions sets the module path and port on a rendered server template, and the DB
+// name when one was given explicitly (an unpinned default could disagree with docker-compose.yaml)
+func applyProjectOptions(projectPath string, o NewProjectOptions, values map[string]string) error {
+	if err := renameGoModule(projectPath, values["module_path"]); err != nil {
+		return err
+	}
+
+	envFilePath := filepath.Join(projectPath, ".env")
+	envValues := map[string]string{"PORT": values["port"]}
+	if o.DBName != "" {
+		if dsn := readEnvValue(envFilePath, "DSN", ""); dsn != "" {
+			envValues["DSN"] = replaceDSNDatabase(dsn, o.DBName)
+		}
+		if err := useComposeDBName(filepath.Join(projectPath, "docker-compose.yaml"), o.DBName); err != nil {
+			fmt.Println("⚠️ Could not set the database name in docker-compose.yaml:", err)
+		}
+	}
+	if err := writeEnvValues(envFilePath, envValues); err != nil {
+		return err
+	}
+
+	if o.Database == "sqlite" {
+		return useSQLite(projectPath, values["db_name"])
+	}
 	return nil
 }
 
@@ -147,31 +234,6 @@ func checkIfProjectExists(projectPath string) error {
 // 	return nil
 // }
 
-func cloneRepoAndPrepare(repoURL, destDir string) error {
-	// Ensure the destination directory does not already exist
-	if _, err := os.Stat(destDir); !os.IsNotExist(err) {
-		return fmt.Errorf("❌ Destination directory '%s' already exists", destDir)
-	}
-
-	// Clone the repository
-	fmt.Println("🚀 Cloning repository:", repoURL, "to", destDir)
-	cmd := exec.Command("git", "clone", "--depth", "1", repoURL, destDir)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("❌ Failed to clone repository: %v", err)
-	}
-
-	// Remove the .git directory
-	gitDir := filepath.Join(destDir, ".git")
-	if err := os.RemoveAll(gitDir); err != nil {
-		return fmt.Errorf("❌ Failed to remove .git directory: %v", err)
-	}
-
-	fmt.Println("✅ Repository cloned and .git folder removed successfully!")
-	return nil
-}
-
 func copyFile(src, dest string) error {
 	sourceFile, err := os.Open(src)
 	if err != nil {
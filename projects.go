@@ -1,17 +1,53 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
+// ProjectData is project.json. Version is the schema version; older files are migrated when
+// they're read (see projectMigrations) and fields Genesis doesn't know are kept as they are.
 type ProjectData struct {
-	Name        string `json:"name"`
-	Database    string `json:"database"`
-	Description string `json:"description"`
+	Version         int                `json:"version"`
+	Name            string             `json:"name"`
+	Database        string             `json:"database"`
+	Description     string             `json:"description"`
+	CreatedAt       string             `json:"createdAt,omitempty"`
+	UpdatedAt       string             `json:"updatedAt,omitempty"`
+	Tags            []string           `json:"tags,omitempty"`
+	Owner           string             `json:"owner,omitempty"`
+	Template        string             `json:"template,omitempty"`        // Template the server was created from
+	TemplateVersion string             `json:"templateVersion,omitempty"` // Ref or commit of that template
+	Health          *HealthCheckConfig `json:"health,omitempty"`
+	Planets         map[string]string  `json:"planets,omitempty"` // Planet directory → planet type
+	ModulePath      string             `json:"modulePath,omitempty"`
+
+	extra map[string]json.RawMessage
+}
+
+func (p *ProjectData) UnmarshalJSON(data []byte) error {
+	type plain ProjectData
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	extra, err := splitUnknownFields(data, plain{})
+	p.extra = extra
+	return err
+}
+
+func (p ProjectData) MarshalJSON() ([]byte, error) {
+	type plain ProjectData
+	data, err := json.Marshal(plain(p))
+	if err != nil {
+		return nil, err
+	}
+	return appendUnknownFields(data, p.extra)
 }
+
 type ProjectInfo struct {
 	Dir     string      `json:"dir"`
 	Project ProjectData `json:"project"`
@@ -42,6 +78,9 @@ type NewProjectOptions struct {
 	Name        string `json:"name"`
 	Database    string `json:"database"`
 	Description string `json:"description"`
+	ModulePath  string `json:"modulePath"` // Go module path, defaults to the directory name
+	DBName      string `json:"dbName"`     // Defaults to the directory name with underscores
+	Port        string `json:"port"`       // Server port, defaults to the project's port range
 }
 
 func (a *App) CreateProject(o NewProjectOptions) error {
@@ -57,29 +96,37 @@ func (a *App) CreateProject(o NewProjectOptions) error {
 		return err
 	}
 
-	// Define the repo URL for the solar system template
-	repoURL := "https://github.com/brightsidedeveloper/bsd-solar-system.git"
 	destPath := filepath.Join(projectPath, o.Dir+"-star")
+	values := projectTemplateValues(o)
 
-	// Clone project template from GitHub
-	if err := cloneRepoAndPrepare(repoURL, destPath); err != nil {
-		return fmt.Errorf("❌ Failed to clone project template: %v", err)
+	// Install the solar system template (pinned or local when overridden in templates.json)
+	if err := a.installTemplate("solar-system", destPath, values); err != nil {
+		return fmt.Errorf("❌ Failed to install project template: %v", err)
+	}
+
+	// Templates without a manifest still carry their own module path, DB name and port
+	if err := applyProjectOptions(destPath, o, values); err != nil {
+		return fmt.Errorf("❌ Failed to configure project template: %v", err)
 	}
 
 	// Create and write project.json with correct metadata
 	projectFilePath := filepath.Join(projectPath, "project.json")
 	projectData := ProjectData{
-		Name:        o.Name,
-		Database:    o.Database,
-		Description: o.Description,
+		Name:            o.Name,
+		Database:        o.Database,
+		Description:     o.Description,
+		ModulePath:      values["module_path"],
+		Template:        "solar-system",
+		TemplateVersion: a.templateVersion("solar-system"),
 	}
 
-	if err := writeJSON(projectFilePath, projectData); err != nil {
+	if err := writeProjectJSON(projectFilePath, projectData); err != nil {
 		return fmt.Errorf("❌ Failed to write project.json: %v", err)
 	}
 
 	apexFilePath := filepath.Join(projectPath, "apex.json")
 	apexData := ApexData{
+		Version:    len(apexMigrations),
 		Endpoints:  []Endpoint{},
 		Schemas:    []Schema{},
 		Operations: []Operation{},
@@ -125,6 +172,54 @@ func (a *App) DeleteProject(dir string) error {
 	return nil
 }
 
+// ProjectMetadata is the user-editable part of project.json
+type ProjectMetadata struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Owner       string   `json:"owner"`
+}
+
+// UpdateProjectMetadata sets the name, description, tags and owner of a project
+func (a *App) UpdateProjectMetadata(dir string, m ProjectMetadata) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(strings.TrimSpace(m.Name)) == 0 {
+		return fmt.Errorf("❌ Project name cannot be empty")
+	}
+
+	projectFilePath := filepath.Join(getSolarDir(a.ProjectsDir), dir, "project.json")
+	unlock := lockFile(projectFilePath)
+	defer unlock()
+
+	projectData, err := readProjectJSONUnlocked(projectFilePath)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to read project.json: %v", err)
+	}
+
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range m.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	projectData.Name = m.Name
+	projectData.Description = m.Description
+	projectData.Tags = tags
+	projectData.Owner = m.Owner
+	if err := writeProjectJSONUnlocked(projectFilePath, projectData); err != nil {
+		return fmt.Errorf("❌ Failed to write project.json: %v", err)
+	}
+
+	fmt.Println("✅ Project metadata saved:", dir)
+	return nil
+}
+
 // OpenProjectInVSCode opens the given project directory in VS Code
 func (a *App) OpenProjectInVSCode(dir string) error {
 	projectPath := filepath.Join(getSolarDir(a.ProjectsDir), dir)
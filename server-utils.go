@@ -6,6 +6,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 )
 
 func setupGoModule(projectPath string) error {
@@ -13,7 +16,7 @@ func setupGoModule(projectPath string) error {
 	goModPath := filepath.Join(projectPath, "go.mod")
 	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
 		fmt.Println("⚠️ go.mod missing, initializing Go module...")
-		if err := runCommandWithError(projectPath, "go", "mod", "init", "module_name"); err != nil {
+		if err := runCommandWithError(projectPath, "go", "mod", "init", serverModulePath(projectPath)); err != nil {
 			return fmt.Errorf("failed to initialize Go module: %v", err)
 		}
 	}
@@ -27,19 +30,118 @@ func setupGoModule(projectPath string) error {
 	return nil
 }
 
-func runCommandWithError(dir, name string, args ...string) error {
-	cmd := exec.Command(name, args...)
-	cmd.Dir = dir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// serverModulePath returns the Go module path of a -star server: what go.mod declares, else
+// what project.json recorded at creation, else one derived from the project directory
+func serverModulePath(projectPath string) string {
+	if modulePath := readGoModulePath(projectPath); modulePath != "" {
+		return modulePath
+	}
 
-	return cmd.Run()
+	projectRoot := filepath.Dir(projectPath)
+	if projectData, err := readProjectJSON(filepath.Join(projectRoot, "project.json")); err == nil && projectData.ModulePath != "" {
+		return projectData.ModulePath
+	}
+	return defaultModulePath(filepath.Base(projectRoot))
+}
+
+// readEnvValue returns the value of key in a .env file, or fallback if the file or key is missing
+func readEnvValue(envFilePath, key, fallback string) string {
+	env, err := parseEnvFile(envFilePath)
+	if err != nil {
+		fmt.Printf("⚠️ Failed to read .env file, returning default %s=%s\n", key, fallback)
+		return fallback
+	}
+
+	value, exists := env.get(key)
+	if !exists {
+		fmt.Printf("⚠️ %s= not found in .env, returning default %s\n", key, fallback)
+		return fallback
+	}
+	return value
+}
+
+// killServerProcesses kills every process whose command line references the project
+// (including the binary `go run` leaves behind) and waits until they are gone
+func killServerProcesses(dir, projectPath string) error {
+	fmt.Println("🔍 Searching for orphaned Go processes related to:", dir)
+
+	// Binaries built by BuildServer live outside the -star folder
+	buildDir := filepath.Join(filepath.Dir(projectPath), ".genesis", "build")
+
+	// ✅ Loop to ensure the process is fully stopped
+	timeout := 10 * time.Second             // Max wait time
+	checkInterval := 500 * time.Millisecond // Check every 500ms
+	deadline := time.Now().Add(timeout)
+
+	for {
+		// 🔍 Search for the running process
+		cmd := exec.Command("ps", "aux")
+		output, err := cmd.Output()
+		if err != nil {
+			fmt.Println("⚠️ Error running ps aux:", err)
+			break
+		}
+
+		// ✅ Parse process list
+		lines := strings.Split(string(output), "\n")
+		processFound := false
+		for _, line := range lines {
+			if strings.Contains(line, projectPath) || strings.Contains(line, buildDir) || strings.Contains(line, "exe/main") {
+				processFound = true
+				fields := strings.Fields(line)
+				if len(fields) > 1 {
+					pid := fields[1]
+					fmt.Println("🔪 Killing orphaned Go process:", pid)
+					exec.Command("kill", "-9", pid).Run()
+				}
+			}
+		}
+
+		// ✅ If no process found, break the loop
+		if !processFound {
+			fmt.Println("✅ All orphaned Go processes stopped.")
+			break
+		}
+
+		// ❌ If timeout is reached, return an error
+		if time.Now().After(deadline) {
+			return fmt.Errorf("❌ Timeout: Process for %s did not stop in time", dir)
+		}
+
+		time.Sleep(checkInterval) // ✅ Wait and check again
+	}
+
+	return nil
 }
 
-func runCommand(name string, args ...string) error {
+// writeEnvValues sets each key in a .env file in place, appending missing keys in sorted order.
+// Comments and every other line are kept as-is.
+func writeEnvValues(envFilePath string, values map[string]string) error {
+	env, err := parseEnvFile(envFilePath)
+	if os.IsNotExist(err) {
+		env = &envFile{}
+	} else if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		env.set(key, values[key])
+	}
+	return env.write(envFilePath)
+}
+
+func runCommandWithError(dir, name string, args ...string) error {
 	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+
 	return cmd.Run()
 }
 
@@ -1,13 +1,11 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
 )
 
 func (a *App) StartServer(dir string) error {
@@ -28,30 +26,68 @@ func (a *App) StartServer(dir string) error {
 		return fmt.Errorf("❌ main.go file is missing in project: %s", projectPath)
 	}
 
-	// Ensure Go dependencies are installed
-	if err := setupGoModule(projectPath); err != nil {
-		return fmt.Errorf("❌ Failed to setup Go module: %v", err)
+	// Ensure Go dependencies are installed (only tidy when the module isn't set up yet)
+	if _, err := os.Stat(filepath.Join(projectPath, "go.sum")); os.IsNotExist(err) {
+		if err := setupGoModule(projectPath); err != nil {
+			return fmt.Errorf("❌ Failed to setup Go module: %v", err)
+		}
 	}
 
-	// Start Docker Compose
-	fmt.Println("🚀 Starting Docker Compose for:", dir)
-	if err := runCommand("docker-compose", "-f", composeFile, "up", "-d"); err != nil {
-		return fmt.Errorf("❌ Failed to start Docker Compose: %v", err)
+	// Compile the server before touching Docker so compile errors fail fast
+	build, err := a.BuildServer(dir, BuildOptions{})
+	if err != nil {
+		return err
+	}
+	if !build.Success {
+		return fmt.Errorf("❌ Failed to build Go application: %s", summarizeDiagnostics(build))
 	}
 
-	fmt.Println("✅ Docker Compose started! Now launching Go application...")
+	// Make sure nothing else is holding the server or database port
+	envFilePath := filepath.Join(projectPath, ".env")
+	port := readEnvValue(envFilePath, "PORT", defaultServerPort(dir))
+	if !a.isManagedProcessRunning(serverProcessID(dir)) {
+		if err := ensurePortFree(port, "the server"); err != nil {
+			return err
+		}
+	}
+	if dbPort := readEnvValue(envFilePath, "DB_PORT", ""); dbPort != "" && !a.isDBRunning(dir) {
+		if err := ensurePortFree(dbPort, "the database"); err != nil {
+			return err
+		}
+	}
+
+	// Start Docker Compose (SQLite projects may have nothing to run in containers)
+	if fileExists(composeFile) {
+		compose, err := a.containerRuntime()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("🚀 Starting", compose.Name, "for:", dir)
+		if err := compose.run(composeFile, "up", "-d"); err != nil {
+			return fmt.Errorf("❌ Failed to start Docker Compose: %v", err)
+		}
+
+		fmt.Println("✅ Docker Compose started!")
+	}
+
+	fmt.Println("🚀 Launching Go application", build.Version, "...")
 
 	// ✅ Set the working directory for the Go process
-	goProcess := exec.Command("go", "run", "main.go")
+	goProcess := exec.Command(build.BinaryPath)
 	goProcess.Dir = projectPath // ✅ Correctly sets the working directory
-	goProcess.Stdout = os.Stdout
-	goProcess.Stderr = os.Stderr
+	// ✅ The server listens on the port Genesis probes, even when .env has no PORT yet
+	goProcess.Env = append(os.Environ(), "PORT="+port)
 
-	// Start process
-	if err := goProcess.Start(); err != nil {
+	// Start process and capture its output
+	proc, err := a.startManagedProcess(serverProcessID(dir), goProcess)
+	if err != nil {
 		return fmt.Errorf("❌ Failed to start Go application: %v", err)
 	}
 
+	// ✅ Watch the server until it is actually serving
+	a.monitorServer(dir, proc, a.GetHealthCheck(dir), port)
+
 	fmt.Println("✅ Go application is now running for:", dir)
 	return nil
 }
@@ -64,55 +100,26 @@ func (a *App) StopServer(dir string) error {
 	projectPath := filepath.Join(filepath.Join(getSolarDir(a.ProjectsDir), dir), dir+"-star")
 	composeFile := filepath.Join(projectPath, "docker-compose.yaml")
 
-	// ✅ Kill orphaned Go processes
-	fmt.Println("🔍 Searching for orphaned Go processes related to:", dir)
-
-	// ✅ Loop to ensure the process is fully stopped
-	timeout := 10 * time.Second             // Max wait time
-	checkInterval := 500 * time.Millisecond // Check every 500ms
-	deadline := time.Now().Add(timeout)
+	// ✅ Stop the process Genesis started, then any orphans it left behind
+	a.stopServerMonitor(dir)
+	a.stopManagedProcess(serverProcessID(dir))
 
-	for {
-		// 🔍 Search for the running process
-		cmd := exec.Command("ps", "aux")
-		output, err := cmd.Output()
-		if err != nil {
-			fmt.Println("⚠️ Error running ps aux:", err)
-			break
-		}
-
-		// ✅ Parse process list
-		lines := strings.Split(string(output), "\n")
-		processFound := false
-		for _, line := range lines {
-			if strings.Contains(line, projectPath) || strings.Contains(line, "exe/main") {
-				processFound = true
-				fields := strings.Fields(line)
-				if len(fields) > 1 {
-					pid := fields[1]
-					fmt.Println("🔪 Killing orphaned Go process:", pid)
-					exec.Command("kill", "-9", pid).Run()
-				}
-			}
-		}
-
-		// ✅ If no process found, break the loop
-		if !processFound {
-			fmt.Println("✅ All orphaned Go processes stopped.")
-			break
-		}
-
-		// ❌ If timeout is reached, return an error
-		if time.Now().After(deadline) {
-			return fmt.Errorf("❌ Timeout: Process for %s did not stop in time", dir)
-		}
-
-		time.Sleep(checkInterval) // ✅ Wait and check again
+	// ✅ Kill orphaned Go processes
+	if err := killServerProcesses(dir, projectPath); err != nil {
+		return err
 	}
 
 	// ✅ Stop Docker Compose
-	fmt.Println("🛑 Stopping Docker Compose for:", dir)
-	if err := runCommand("docker-compose", "-f", composeFile, "down"); err != nil {
+	if !fileExists(composeFile) {
+		return nil
+	}
+	compose, err := a.containerRuntime()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🛑 Stopping", compose.Name, "for:", dir)
+	if err := compose.run(composeFile, "down"); err != nil {
 		return fmt.Errorf("❌ Failed to stop Docker Compose: %v", err)
 	}
 
@@ -134,6 +141,11 @@ func (a *App) RestartServer(dir string) error {
 		return fmt.Errorf("❌ Failed to start server: %v", err)
 	}
 
+	// ✅ Step 3: Wait until the new instance is actually serving
+	if _, err := a.WaitForServerReady(dir, 0); err != nil {
+		return fmt.Errorf("❌ Server did not become ready: %v", err)
+	}
+
 	fmt.Println("✅ Server restarted successfully for:", dir)
 	return nil
 }
@@ -145,78 +157,27 @@ func (a *App) GetPort(dir string) string {
 	projectPath := filepath.Join(filepath.Join(getSolarDir(a.ProjectsDir), dir), dir+"-star")
 	envFilePath := filepath.Join(projectPath, ".env")
 
-	// ✅ Ensure .env file exists
-	if _, err := os.Stat(envFilePath); os.IsNotExist(err) {
-		fmt.Println("⚠️ .env file not found, returning default port 8080")
-		return "8080"
-	}
-
-	file, err := os.Open(envFilePath)
-	if err != nil {
-		fmt.Printf("❌ Failed to open .env file: %v\n", err)
-		return "8080"
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text()) // ✅ Trim whitespace to avoid issues
-		if strings.HasPrefix(line, "PORT=") {
-			fmt.Println("✅ Found PORT= in .env file:", line)
-			return strings.TrimSpace(strings.TrimPrefix(line, "PORT="))
-		}
-	}
-
-	fmt.Println("⚠️ PORT= not found in .env, returning default 8080")
-	return "8080"
+	return readEnvValue(envFilePath, "PORT", defaultServerPort(dir))
 }
 
 func (a *App) UpdatePort(dir, newPort string) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	projectPath := filepath.Join(filepath.Join(getSolarDir(a.ProjectsDir), dir), dir+"-star")
-	envFilePath := filepath.Join(projectPath, ".env")
-
-	// ✅ Ensure .env file exists before modifying
-	if _, err := os.Stat(envFilePath); os.IsNotExist(err) {
-		fmt.Println("⚠️ .env file not found, creating a new one with PORT=" + newPort)
-		return os.WriteFile(envFilePath, []byte("PORT="+newPort+"\n"), 0644)
+	if _, err := parsePort(newPort); err != nil {
+		return err
 	}
-
-	file, err := os.Open(envFilePath)
-	if err != nil {
-		return fmt.Errorf("❌ Failed to open .env file: %v", err)
-	}
-	defer file.Close()
-
-	var updatedLines []string
-	scanner := bufio.NewScanner(file)
-	portUpdated := false
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text()) // ✅ Trim whitespace
-		if strings.HasPrefix(line, "PORT=") {
-			updatedLines = append(updatedLines, "PORT="+newPort)
-			portUpdated = true
-		} else {
-			updatedLines = append(updatedLines, line)
+	if newPort != readEnvValue(filepath.Join(getSolarDir(a.ProjectsDir), dir, dir+"-star", ".env"), "PORT", "") {
+		if err := ensurePortFree(newPort, "the server"); err != nil {
+			return err
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("❌ Failed to read .env file: %v", err)
-	}
-
-	// ✅ If no PORT= entry was found, add it at the end
-	if !portUpdated {
-		fmt.Println("⚠️ PORT= not found, adding PORT=" + newPort)
-		updatedLines = append(updatedLines, "PORT="+newPort)
-	}
+	projectPath := filepath.Join(filepath.Join(getSolarDir(a.ProjectsDir), dir), dir+"-star")
+	envFilePath := filepath.Join(projectPath, ".env")
 
-	// ✅ Write the updated lines back to the `.env` file
-	err = os.WriteFile(envFilePath, []byte(strings.Join(updatedLines, "\n")+"\n"), 0644)
-	if err != nil {
+	// ✅ Updates PORT= in place (or appends it), keeping comments and other keys
+	if err := writeEnvValues(envFilePath, map[string]string{"PORT": newPort}); err != nil {
 		return fmt.Errorf("❌ Failed to write .env file: %v", err)
 	}
 
@@ -228,40 +189,51 @@ func (a *App) UpdatePort(dir, newPort string) error {
 type ServerStatus struct {
 	DB     string `json:"db"`     // "running" | "stopped"
 	Server string `json:"server"` // "running" | "stopped"
+	State  string `json:"state"`  // "stopped" | "starting" | "ready" | "unhealthy" | "crashed"
 }
 
 // ✅ Function to Check Server & DB Status
 func (a *App) GetServerStatus(dir string) ServerStatus {
 	projectPath := filepath.Join(filepath.Join(getSolarDir(a.ProjectsDir), dir), dir+"-star")
-	composeFile := filepath.Join(projectPath, "docker-compose.yaml")
 
 	status := ServerStatus{
 		DB:     "stopped",
 		Server: "stopped",
+		State:  a.GetServerHealth(dir).State,
 	}
 
 	// ✅ Step 1: Check if the Go application is running
-	cmd := exec.Command("pgrep", "-fl", "go")
-	output, err := cmd.Output()
-	if err == nil {
-		lines := strings.Split(string(output), "\n")
-		for _, line := range lines {
-			if strings.Contains(line, projectPath) || strings.Contains(line, "exe/main") {
-				status.Server = "running"
-				break
+	if a.isManagedProcessRunning(serverProcessID(dir)) {
+		status.Server = "running"
+	} else {
+		buildDir := filepath.Join(filepath.Dir(projectPath), ".genesis", "build")
+		cmd := exec.Command("ps", "ax", "-o", "pid=,command=")
+		output, err := cmd.Output()
+		if err == nil {
+			lines := strings.Split(string(output), "\n")
+			for _, line := range lines {
+				if strings.Contains(line, projectPath) || strings.Contains(line, buildDir) || strings.Contains(line, "exe/main") {
+					status.Server = "running"
+					break
+				}
 			}
 		}
 	}
 
-	// ✅ Step 2: Check if Docker DB service is running
-	cmd = exec.Command("docker-compose", "-f", composeFile, "ps", "--services", "--filter", "status=running")
-	output, err = cmd.Output()
-	if err == nil {
-		runningServices := strings.TrimSpace(string(output))
-		if strings.Contains(runningServices, "db") { // Assuming your DB service is named "db"
-			status.DB = "running"
+	// ✅ Servers Genesis didn't start itself get a one-off probe
+	if status.State == ServerStopped && status.Server == "running" {
+		port := readEnvValue(filepath.Join(projectPath, ".env"), "PORT", defaultServerPort(dir))
+		if err := probeServer(a.GetHealthCheck(dir), port); err != nil {
+			status.State = ServerUnhealthy
+		} else {
+			status.State = ServerReady
 		}
 	}
 
+	// ✅ Step 2: Check if the database service is running
+	if a.isDBRunning(dir) {
+		status.DB = "running"
+	}
+
 	return status
 }
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileLocks holds one mutex per absolute file path. Entries are never removed: there's one per
+// JSON file Genesis has written since it started, which stays small.
+var fileLocks sync.Map
+
+// lockFile serializes read-modify-write cycles on one file and returns the unlock function.
+// Locks aren't reentrant, so helpers called with a lock held must use the unlocked variants.
+func lockFile(path string) func() {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	value, _ := fileLocks.LoadOrStore(path, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// fileRevision identifies a version of a file's content. The UI sends it back on save so a
+// stale editor can't overwrite newer changes.
+func fileRevision(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// checkRevision fails when the file no longer has the revision the caller loaded. An empty
+// revision skips the check, and a missing file has the revision "".
+func checkRevision(path, revision string) error {
+	if revision == "" {
+		return nil
+	}
+
+	current := ""
+	if data, err := os.ReadFile(path); err == nil {
+		current = fileRevision(data)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if current != revision {
+		return fmt.Errorf("❌ %s was changed since it was loaded. Reload it and try again", filepath.Base(path))
+	}
+	return nil
+}
+
+// writeFileAtomic writes to a temporary file next to path, syncs it and renames it over path,
+// so a crash leaves either the old or the new content, never a truncated file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// Persist the rename itself. Directories can't be synced on Windows, where rename is durable.
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
+
+// marshalJSON formats data the way every Genesis JSON file is written
+func marshalJSON(data interface{}) ([]byte, error) {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(encoded, '\n'), nil
+}
+
+// writeJSONUnlocked is writeJSON for callers that already hold the file's lock. It returns the
+// new revision.
+func writeJSONUnlocked(filePath string, data interface{}) (string, error) {
+	encoded, err := marshalJSON(data)
+	if err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(filePath, encoded, 0644); err != nil {
+		return "", err
+	}
+	return fileRevision(encoded), nil
+}
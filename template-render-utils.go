@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// templateManifestFile sits at the root of a template and is removed once the template is rendered
+const templateManifestFile = "genesis.template.json"
+
+// templateManifest declares the variables a template uses
+type templateManifest struct {
+	Variables []templateVariable `json:"variables"`
+	Exclude   []string           `json:"exclude"`   // Globs of files copied without rendering
+	Databases []string           `json:"databases"` // Databases a server template works with, Postgres when empty
+}
+
+// supportsDatabase reports whether a server template can run against a database. Templates
+// without a manifest or a databases list are written for Postgres.
+func (m templateManifest) supportsDatabase(database string) bool {
+	if database == "" {
+		database = "postgres"
+	}
+	if len(m.Databases) == 0 {
+		return database == "postgres"
+	}
+	return toSet(m.Databases)[database]
+}
+
+// templateVariable is rendered wherever "{{name}}" appears in a file's contents or path.
+// Replace additionally swaps a literal, which lets a template stay buildable as-is
+// (e.g. replace "solar-system" with the module path).
+type templateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     string `json:"default"`
+	Replace     string `json:"replace"`
+	Required    bool   `json:"required"`
+}
+
+// Matches "{{ name }}" placeholders; only names the manifest declares are substituted
+var templatePlaceholderRegex = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+
+// Matches a Go module path
+var modulePathRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~-]*(/[A-Za-z0-9._~-]+)*$`)
+
+// readTemplateManifest loads genesis.template.json from a rendered template, if it has one
+func readTemplateManifest(root string) (templateManifest, bool, error) {
+	data, err := os.ReadFile(filepath.Join(root, templateManifestFile))
+	if os.IsNotExist(err) {
+		return templateManifest{}, false, nil
+	}
+	if err != nil {
+		return templateManifest{}, false, err
+	}
+
+	var manifest templateManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return templateManifest{}, false, fmt.Errorf("invalid %s: %v", templateManifestFile, err)
+	}
+	return manifest, true, nil
+}
+
+// renderTemplate substitutes the variables declared by genesis.template.json into the contents
+// and file names of an installed template. Templates without a manifest are left as they are.
+func renderTemplate(root string, values map[string]string) error {
+	manifest, found, err := readTemplateManifest(root)
+	if err != nil {
+		return err
+	}
+	// Only server templates get a database to check against
+	if database, exists := values["database"]; exists && !manifest.supportsDatabase(database) {
+		return fmt.Errorf("the template doesn't support %s databases", database)
+	}
+	if !found {
+		return nil
+	}
+
+	// Manifest defaults fill in what the caller didn't provide
+	resolved := make(map[string]string)
+	var replacements []string
+	for _, variable := range manifest.Variables {
+		resolved[variable.Name] = values[variable.Name]
+		if resolved[variable.Name] == "" {
+			resolved[variable.Name] = variable.Default
+		}
+		if resolved[variable.Name] == "" && variable.Required {
+			return fmt.Errorf("template variable %s is required", variable.Name)
+		}
+		if variable.Replace != "" && resolved[variable.Name] != "" {
+			replacements = append(replacements, variable.Replace, resolved[variable.Name])
+		}
+	}
+	literals := strings.NewReplacer(replacements...)
+
+	render := func(text string) string {
+		text = templatePlaceholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+			name := templatePlaceholderRegex.FindStringSubmatch(placeholder)[1]
+			if value, exists := resolved[name]; exists {
+				return value
+			}
+			return placeholder
+		})
+		if len(replacements) > 0 {
+			text = literals.Replace(text)
+		}
+		return text
+	}
+
+	var renames []string
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && (info.Name() == ".git" || info.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		if templatePlaceholderRegex.MatchString(info.Name()) {
+			renames = append(renames, path)
+		}
+		if info.IsDir() || !info.Mode().IsRegular() || rel == templateManifestFile || matchesAnyGlob(rel, manifest.Exclude) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if isBinaryContent(data) {
+			return nil
+		}
+
+		rendered := render(string(data))
+		if rendered == string(data) {
+			return nil
+		}
+		return os.WriteFile(path, []byte(rendered), info.Mode().Perm())
+	})
+	if err != nil {
+		return err
+	}
+
+	// Rename the deepest paths first so parents still exist under their old names
+	sort.Slice(renames, func(i, j int) bool { return len(renames[i]) > len(renames[j]) })
+	for _, path := range renames {
+		renamed := filepath.Join(filepath.Dir(path), render(filepath.Base(path)))
+		if renamed != path {
+			if err := os.Rename(path, renamed); err != nil {
+				return err
+			}
+		}
+	}
+
+	os.Remove(filepath.Join(root, templateManifestFile))
+	return nil
+}
+
+// matchesAnyGlob matches a slash-separated relative path, or its base name, against globs
+func matchesAnyGlob(rel string, globs []string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, glob := range globs {
+		if matched, _ := filepath.Match(glob, rel); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(glob, filepath.Base(rel)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isBinaryContent treats files with a NUL byte near the start as binary, like git does
+func isBinaryContent(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// readGoModulePath returns the module path declared in a go.mod, or "" when there is none
+func readGoModulePath(projectPath string) string {
+	data, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`)
+		}
+	}
+	return ""
+}
+
+// renameGoModule changes the module path in go.mod and every import of it in .go files, so
+// templates without a manifest still get the project's module path
+func renameGoModule(projectPath, modulePath string) error {
+	current := readGoModulePath(projectPath)
+	if current == "" || current == modulePath {
+		return nil
+	}
+
+	goModPath := filepath.Join(projectPath, "go.mod")
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return err
+	}
+	moduleLine := regexp.MustCompile(`(?m)^module\s+.*$`)
+	if err := os.WriteFile(goModPath, moduleLine.ReplaceAll(data, []byte("module "+modulePath)), 0644); err != nil {
+		return err
+	}
+
+	imports := strings.NewReplacer(`"`+current+`"`, `"`+modulePath+`"`, `"`+current+`/`, `"`+modulePath+`/`)
+	return filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && (info.Name() == ".git" || info.Name() == "vendor" || info.Name() == ".genesis") {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		updated := imports.Replace(string(data))
+		if updated == string(data) {
+			return nil
+		}
+		return os.WriteFile(path, []byte(updated), info.Mode().Perm())
+	})
+}
+
+// defaultModulePath derives a Go module path from a project directory name
+func defaultModulePath(dir string) string {
+	modulePath := strings.ToLower(strings.Join(strings.Fields(dir), "-"))
+	if !modulePathRegex.MatchString(modulePath) {
+		return "solar-system"
+	}
+	return modulePath
+}
+
+// defaultDBName derives a database name from a project directory name
+func defaultDBName(dir string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '_'
+		}
+	}, dir)
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "db_" + name
+	}
+	return name
+}
+
+// Matches the database name of a URL-style DSN: ".../<name>" up to "?" or the end
+var dsnDatabaseRegex = regexp.MustCompile(`^([a-z]+://[^/]*/)([^?]*)`)
+
+// replaceDSNDatabase points a URL-style DSN at another database
+func replaceDSNDatabase(dsn, database string) string {
+	return dsnDatabaseRegex.ReplaceAllString(dsn, "${1}"+database)
+}
+
+// Matches "POSTGRES_DB: name" / "- MYSQL_DATABASE=name" in docker-compose.yaml
+var composeDBNameRegex = regexp.MustCompile(`(?m)^([ \t]*-?[ \t]*(?:POSTGRES_DB|MYSQL_DATABASE)[ \t]*[:=][ \t]*)["']?[^"'\s]*["']?([ \t]*)$`)
+
+// useComposeDBName sets the database the compose file creates
+func useComposeDBName(composeFile, name string) error {
+	data, err := os.ReadFile(composeFile)
+	if err != nil {
+		return err
+	}
+	if !composeDBNameRegex.Match(data) {
+		return fmt.Errorf("no POSTGRES_DB or MYSQL_DATABASE found")
+	}
+
+	updated := composeDBNameRegex.ReplaceAllString(string(data), "${1}"+name+"${2}")
+	return os.WriteFile(composeFile, []byte(updated), 0644)
+}
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// defaultTemplates are available without any templates.json
+var defaultTemplates = []TemplateSource{
+	{
+		Name:        "solar-system",
+		Kind:        "git",
+		Location:    "https://github.com/brightsidedeveloper/bsd-solar-system.git",
+		Description: "Go server with Postgres",
+		BuiltIn:     true,
+	},
+	{
+		Name:        "planet-web",
+		Kind:        "git",
+		Location:    "https://github.com/brightsidedeveloper/bsd-planet-web.git",
+		Description: "React web app",
+		BuiltIn:     true,
+	},
+	{
+		Name:        "planet-mobile",
+		Kind:        "git",
+		Location:    "https://github.com/brightsidedeveloper/bsd-planet-mobile.git",
+		Description: "Mobile app",
+		BuiltIn:     true,
+	},
+	{
+		Name:        "planet-desktop",
+		Kind:        "git",
+		Location:    "https://github.com/brightsidedeveloper/bsd-planet-desktop.git",
+		Description: "Desktop app",
+		BuiltIn:     true,
+	},
+}
+
+// templateConfig is the layout of GENESIS_PATH/genesis/templates.json
+type templateConfig struct {
+	Templates []TemplateSource `json:"templates"`
+}
+
+// getTemplateCacheDir returns where git mirrors and downloaded tarballs are kept. It sits next
+// to ~/.wails-genesis rather than inside it because that path is the settings file.
+func getTemplateCacheDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".wails-genesis-cache", "templates")
+}
+
+func (a *App) templateConfigPath() string {
+	return filepath.Join(a.ProjectsDir, "genesis", "templates.json")
+}
+
+// readTemplateConfig returns the templates from templates.json, or none when it doesn't exist
+func (a *App) readTemplateConfig() []TemplateSource {
+	data, err := os.ReadFile(a.templateConfigPath())
+	if err != nil {
+		return []TemplateSource{}
+	}
+
+	var config templateConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		fmt.Println("⚠️ Ignoring invalid templates.json:", err)
+		return []TemplateSource{}
+	}
+	return config.Templates
+}
+
+func (a *App) writeTemplateConfig(templates []TemplateSource) error {
+	if err := ensureDir(filepath.Dir(a.templateConfigPath())); err != nil {
+		return err
+	}
+	return writeJSON(a.templateConfigPath(), templateConfig{Templates: templates})
+}
+
+// loadTemplateRegistry merges templates.json over the built-in templates
+func (a *App) loadTemplateRegistry() []TemplateSource {
+	registry := append([]TemplateSource{}, defaultTemplates...)
+
+	for _, template := range a.readTemplateConfig() {
+		if template.Kind == "" {
+			template.Kind = detectTemplateKind(template.Location)
+		}
+
+		replaced := false
+		for i, existing := range registry {
+			if existing.Name == template.Name {
+				registry[i] = template
+				replaced = true
+			}
+		}
+		if !replaced {
+			registry = append(registry, template)
+		}
+	}
+	return registry
+}
+
+func (a *App) findTemplate(name string) (TemplateSource, bool) {
+	for _, template := range a.loadTemplateRegistry() {
+		if template.Name == name {
+			return template, true
+		}
+	}
+	return TemplateSource{}, false
+}
+
+// resolveTemplate turns a template name, or an inline "<location>#<ref>", into a source
+func (a *App) resolveTemplate(nameOrLocation string) TemplateSource {
+	if template, exists := a.findTemplate(nameOrLocation); exists {
+		return template
+	}
+
+	location, ref, _ := strings.Cut(nameOrLocation, "#")
+	return TemplateSource{
+		Name:     nameOrLocation,
+		Kind:     detectTemplateKind(location),
+		Location: location,
+		Ref:      ref,
+	}
+}
+
+// detectTemplateKind guesses the kind of a template from its location. Local directories are
+// copied as-is unless they are bare git repositories.
+func detectTemplateKind(location string) string {
+	lower := strings.ToLower(location)
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") || strings.HasSuffix(lower, ".tar") {
+		return "tarball"
+	}
+
+	if info, err := os.Stat(location); err == nil && info.IsDir() {
+		if isBareGitRepo(location) {
+			return "git"
+		}
+		return "dir"
+	}
+	return "git"
+}
+
+func isBareGitRepo(path string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// installTemplate copies a template into dest, which must not exist yet, and renders values
+// into it (see genesis.template.json)
+func (a *App) installTemplate(nameOrLocation, dest string, values map[string]string) error {
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		return fmt.Errorf("❌ Destination directory '%s' already exists", dest)
+	}
+
+	template := a.resolveTemplate(nameOrLocation)
+	fmt.Println("📦 Installing template", template.Name, "(", template.Kind, template.Location, template.Ref, ") to", dest)
+
+	source, err := cacheTemplate(template, false)
+	if err != nil {
+		return err
+	}
+
+	switch template.Kind {
+	case "dir":
+		err = copyTemplateDir(source, dest)
+	case "tarball":
+		err = extractTarball(source, dest)
+	default:
+		err = checkoutTemplate(source, template.Ref, dest)
+	}
+	if err == nil {
+		err = renderTemplate(dest, values)
+	}
+	if err != nil {
+		os.RemoveAll(dest)
+		return err
+	}
+
+	fmt.Println("✅ Template installed:", dest)
+	return nil
+}
+
+// cacheTemplate makes sure a template is available locally and returns where it is. Git
+// templates are mirrored and only fetched when the pinned ref is missing (or force is set);
+// if fetching fails, an existing mirror is used so creation keeps working offline.
+func cacheTemplate(template TemplateSource, force bool) (string, error) {
+	switch template.Kind {
+	case "dir":
+		return template.Location, nil
+
+	case "tarball":
+		if !strings.HasPrefix(template.Location, "http://") && !strings.HasPrefix(template.Location, "https://") {
+			return template.Location, nil
+		}
+		cached := filepath.Join(getTemplateCacheDir(), "tarballs", cacheKey(template.Location)+".tar.gz")
+		if _, err := os.Stat(cached); err == nil && !force {
+			return cached, nil
+		}
+		if err := downloadFile(template.Location, cached); err != nil {
+			if _, statErr := os.Stat(cached); statErr == nil {
+				fmt.Println("⚠️ Failed to download template, using cached copy:", err)
+				return cached, nil
+			}
+			return "", fmt.Errorf("❌ Failed to download template: %v", err)
+		}
+		return cached, nil
+
+	default:
+		mirror := filepath.Join(getTemplateCacheDir(), "git", cacheKey(template.Location))
+		if _, err := os.Stat(mirror); os.IsNotExist(err) {
+			if err := ensureDir(filepath.Dir(mirror)); err != nil {
+				return "", err
+			}
+			fmt.Println("🚀 Mirroring template repository:", template.Location)
+			if output, err := exec.Command("git", "clone", "--mirror", "--quiet", template.Location, mirror).CombinedOutput(); err != nil {
+				os.RemoveAll(mirror)
+				return "", fmt.Errorf("❌ Failed to clone template repository: %v\n%s", err, output)
+			}
+			return mirror, nil
+		}
+
+		if !force && template.Ref != "" && gitHasRef(mirror, template.Ref) {
+			return mirror, nil
+		}
+
+		fmt.Println("🔄 Updating template mirror:", template.Location)
+		if output, err := exec.Command("git", "-C", mirror, "remote", "update", "--prune").CombinedOutput(); err != nil {
+			if force {
+				return "", fmt.Errorf("❌ Failed to update template repository: %v\n%s", err, output)
+			}
+			fmt.Println("⚠️ Failed to update template mirror, using cached copy:", err)
+		}
+		return mirror, nil
+	}
+}
+
+// templateVersion identifies what a template installs: the commit a git template's ref (or
+// default branch) points at in the mirror, otherwise its ref if any
+func (a *App) templateVersion(nameOrLocation string) string {
+	template := a.resolveTemplate(nameOrLocation)
+	if template.Kind != "git" {
+		return template.Ref
+	}
+
+	ref := template.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	mirror := filepath.Join(getTemplateCacheDir(), "git", cacheKey(template.Location))
+	output, err := exec.Command("git", "-C", mirror, "rev-parse", "--short=12", ref+"^{commit}").Output()
+	if err != nil {
+		return template.Ref
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func cacheKey(location string) string {
+	sum := sha1.Sum([]byte(location))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func gitHasRef(repo, ref string) bool {
+	return exec.Command("git", "-C", repo, "rev-parse", "--verify", "--quiet", ref+"^{commit}").Run() == nil
+}
+
+// checkoutTemplate clones a (mirrored) repository into dest at ref and drops its .git
+func checkoutTemplate(repo, ref, dest string) error {
+	if output, err := exec.Command("git", "clone", "--quiet", repo, dest).CombinedOutput(); err != nil {
+		return fmt.Errorf("❌ Failed to clone template: %v\n%s", err, output)
+	}
+
+	if ref != "" {
+		if output, err := exec.Command("git", "-C", dest, "checkout", "--quiet", ref).CombinedOutput(); err != nil {
+			return fmt.Errorf("❌ Failed to check out template ref %s: %v\n%s", ref, err, output)
+		}
+	}
+
+	if err := os.RemoveAll(filepath.Join(dest, ".git")); err != nil {
+		return fmt.Errorf("❌ Failed to remove .git directory: %v", err)
+	}
+	return nil
+}
+
+// copyTemplateDir copies a local template, leaving out its git history and installed packages
+func copyTemplateDir(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && (info.Name() == ".git" || info.Name() == "node_modules") && rel != "." {
+			return filepath.SkipDir
+		}
+
+		target := filepath.Join(dest, rel)
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			if err := copyFile(path, target); err != nil {
+				return err
+			}
+			return os.Chmod(target, info.Mode().Perm())
+		}
+	})
+}
+
+// extractTarball unpacks a .tar or .tar.gz into dest. Archives with a single top-level folder,
+// like GitHub's, are unwrapped.
+func extractTarball(archive, dest string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to open template archive: %v", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = bufio.NewReader(file)
+	if magic, err := reader.(*bufio.Reader).Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return fmt.Errorf("❌ Failed to read template archive: %v", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	staging := dest + ".extracting"
+	os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("❌ Failed to read template archive: %v", err)
+		}
+
+		target := filepath.Join(staging, header.Name)
+		if target != staging && !strings.HasPrefix(target, staging+string(filepath.Separator)) {
+			return fmt.Errorf("❌ Template archive contains an unsafe path: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			err = writeTarFile(tarReader, target, os.FileMode(header.Mode).Perm())
+		case tar.TypeSymlink:
+			// Files written later must not land outside dest through a link
+			resolved := filepath.Join(filepath.Dir(target), header.Linkname)
+			if filepath.IsAbs(header.Linkname) || !strings.HasPrefix(resolved, staging+string(filepath.Separator)) {
+				return fmt.Errorf("❌ Template archive contains an unsafe link: %s", header.Name)
+			}
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err == nil {
+				err = os.Symlink(header.Linkname, target)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("❌ Failed to extract %s: %v", header.Name, err)
+		}
+	}
+
+	root := staging
+	if entries, err := os.ReadDir(staging); err == nil && len(entries) == 1 && entries[0].IsDir() {
+		root = filepath.Join(staging, entries[0].Name())
+	}
+	return os.Rename(root, dest)
+}
+
+func writeTarFile(reader io.Reader, target string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode|0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	return err
+}
+
+// downloadFile fetches url into path, honoring HTTP(S)_PROXY from the environment
+func downloadFile(url, path string) error {
+	if err := ensureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	response, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+
+	tmp := path + ".download"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, response.Body); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	file.Close()
+
+	return os.Rename(tmp, path)
+}
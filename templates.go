@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// TemplateSource is where a solar system or planet template comes from
+type TemplateSource struct {
+	Name        string `json:"name"`        // Referenced by CreateProject and planet types, e.g. "solar-system"
+	Kind        string `json:"kind"`        // "git" | "dir" | "tarball"; detected from Location when empty
+	Location    string `json:"location"`    // Git URL or path (bare repos too), directory, or .tar.gz path/URL
+	Ref         string `json:"ref"`         // Git branch, tag or commit to check out; empty uses the default branch
+	Description string `json:"description"` // Shown in the template picker
+	BuiltIn     bool   `json:"builtIn"`     // Shipped with Genesis; saving one with this name overrides it
+}
+
+// GetTemplates returns the built-in templates followed by the ones from templates.json.
+// Entries in templates.json with a built-in name replace the built-in one, which is how a
+// team pins the solar system template to a tag or points it at a local mirror.
+func (a *App) GetTemplates() []TemplateSource {
+	return a.loadTemplateRegistry()
+}
+
+// SaveTemplate adds or replaces a template in templates.json
+func (a *App) SaveTemplate(template TemplateSource) error {
+	if !planetNameRegex.MatchString(template.Name) {
+		return fmt.Errorf("❌ Invalid template name: %s. Use lowercase letters, digits and dashes", template.Name)
+	}
+	if template.Location == "" {
+		return fmt.Errorf("❌ Template '%s' needs a location", template.Name)
+	}
+	if template.Kind == "" {
+		template.Kind = detectTemplateKind(template.Location)
+	}
+	if template.Kind != "git" && template.Kind != "dir" && template.Kind != "tarball" {
+		return fmt.Errorf("❌ Invalid template kind: %s. Must be 'git', 'dir' or 'tarball'", template.Kind)
+	}
+	if template.Kind == "dir" {
+		if info, err := os.Stat(template.Location); err != nil || !info.IsDir() {
+			return fmt.Errorf("❌ Template directory does not exist: %s", template.Location)
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	custom := a.readTemplateConfig()
+	template.BuiltIn = false
+
+	replaced := false
+	for i, existing := range custom {
+		if existing.Name == template.Name {
+			custom[i] = template
+			replaced = true
+		}
+	}
+	if !replaced {
+		custom = append(custom, template)
+	}
+
+	if err := a.writeTemplateConfig(custom); err != nil {
+		return fmt.Errorf("❌ Failed to write templates.json: %v", err)
+	}
+
+	fmt.Println("✅ Saved template:", template.Name)
+	return nil
+}
+
+// DeleteTemplate removes a template from templates.json. Deleting an override of a built-in
+// template restores the built-in one.
+func (a *App) DeleteTemplate(name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	custom := a.readTemplateConfig()
+	kept := []TemplateSource{}
+	for _, template := range custom {
+		if template.Name != name {
+			kept = append(kept, template)
+		}
+	}
+	if len(kept) == len(custom) {
+		return fmt.Errorf("❌ Template '%s' is not defined in templates.json", name)
+	}
+
+	if err := a.writeTemplateConfig(kept); err != nil {
+		return fmt.Errorf("❌ Failed to write templates.json: %v", err)
+	}
+
+	fmt.Println("✅ Deleted template:", name)
+	return nil
+}
+
+// RefreshTemplate fetches a template into the cache, even when a pinned ref is already cached.
+// Run it while online so later project creation works offline.
+func (a *App) RefreshTemplate(name string) error {
+	template, exists := a.findTemplate(name)
+	if !exists {
+		return fmt.Errorf("❌ Unknown template: %s", name)
+	}
+
+	if _, err := cacheTemplate(template, true); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Template cached:", name)
+	return nil
+}
+
+// ClearTemplateCache removes every cached git mirror and tarball
+func (a *App) ClearTemplateCache() error {
+	if err := os.RemoveAll(getTemplateCacheDir()); err != nil {
+		return fmt.Errorf("❌ Failed to clear template cache: %v", err)
+	}
+
+	fmt.Println("🧹 Cleared template cache:", getTemplateCacheDir())
+	return nil
+}
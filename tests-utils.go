@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// goTestEvent is one line of `go test -json` output (see `go doc test2json`)
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// Matches the per-package "coverage: 75.0% of statements" line
+var goCoverageRegex = regexp.MustCompile(`coverage: ([\d.]+)% of statements`)
+
+// runGoTests runs `go <args>` in projectPath and folds its test2json stream into a summary
+func (a *App) runGoTests(dir, projectPath string, args []string, coverProfile string) (TestRunSummary, error) {
+	started := time.Now()
+	processID := testProcessID(dir, "server")
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = projectPath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return TestRunSummary{}, fmt.Errorf("❌ Failed to capture stdout: %v", err)
+	}
+
+	os.Remove(coverProfile)
+	if err := cmd.Start(); err != nil {
+		return TestRunSummary{}, fmt.Errorf("❌ Failed to run go test: %v", err)
+	}
+
+	var output strings.Builder
+	var tests []*TestCaseResult
+	var packages []*TestPackageResult
+	testIndex := make(map[string]*TestCaseResult)
+	packageIndex := make(map[string]*TestPackageResult)
+
+	packageResult := func(name string) *TestPackageResult {
+		result, exists := packageIndex[name]
+		if !exists {
+			result = &TestPackageResult{Package: name, Coverage: -1}
+			packageIndex[name] = result
+			packages = append(packages, result)
+		}
+		return result
+	}
+
+	scanner := bufio.NewScanner(stdoutPipe)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event goTestEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Action == "" {
+			// Not every line is JSON, e.g. when the toolchain itself complains
+			output.WriteString(scanner.Text() + "\n")
+			a.appendLog(processID, "stdout", scanner.Text())
+			continue
+		}
+
+		key := event.Package + " " + event.Test
+		switch event.Action {
+		case "output", "build-output":
+			output.WriteString(event.Output)
+			if text := strings.TrimRight(event.Output, "\n"); text != "" {
+				a.appendLog(processID, "stdout", text)
+			}
+
+			if event.Test != "" {
+				if test, exists := testIndex[key]; exists {
+					test.Output += event.Output
+				}
+			} else if event.Package != "" {
+				pkg := packageResult(event.Package)
+				pkg.Output += event.Output
+				if match := goCoverageRegex.FindStringSubmatch(event.Output); match != nil {
+					pkg.Coverage, _ = strconv.ParseFloat(match[1], 64)
+				}
+			}
+			continue
+
+		case "run":
+			if _, exists := testIndex[key]; !exists {
+				test := &TestCaseResult{Package: event.Package, Name: event.Test}
+				testIndex[key] = test
+				tests = append(tests, test)
+			}
+
+		case "pass", "fail", "skip":
+			if event.Test != "" {
+				test, exists := testIndex[key]
+				if !exists {
+					test = &TestCaseResult{Package: event.Package, Name: event.Test}
+					testIndex[key] = test
+					tests = append(tests, test)
+				}
+				test.Status = event.Action
+				test.Elapsed = event.Elapsed
+			} else if event.Package != "" {
+				pkg := packageResult(event.Package)
+				pkg.Status = event.Action
+				pkg.Elapsed = event.Elapsed
+			}
+
+		default:
+			// "start", "pause", "cont", "bench" and "build-fail" carry nothing the summary needs
+			continue
+		}
+
+		a.emitTestEvent(dir, "server", TestEvent{
+			Action:  event.Action,
+			Package: event.Package,
+			Test:    event.Test,
+			Elapsed: event.Elapsed,
+		})
+	}
+
+	waitErr := cmd.Wait()
+	var exitErr *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exitErr) {
+		return TestRunSummary{}, fmt.Errorf("❌ go test failed: %v", waitErr)
+	}
+
+	if stderr.Len() > 0 {
+		output.WriteString(stderr.String())
+		for _, line := range strings.Split(strings.TrimRight(stderr.String(), "\n"), "\n") {
+			a.appendLog(processID, "stderr", line)
+		}
+	}
+
+	summary := TestRunSummary{
+		Target:      "server",
+		Success:     waitErr == nil,
+		Coverage:    coverProfilePercent(coverProfile),
+		Packages:    []TestPackageResult{},
+		Tests:       []TestCaseResult{},
+		Diagnostics: parseBuildDiagnostics(output.String(), projectPath),
+		Output:      output.String(),
+		Duration:    time.Since(started).Milliseconds(),
+	}
+
+	for _, test := range tests {
+		// A test that never reported a result was cut short by a panic or timeout
+		if test.Status == "" {
+			test.Status = "fail"
+		}
+		switch test.Status {
+		case "pass":
+			summary.Passed++
+		case "fail":
+			summary.Failed++
+		case "skip":
+			summary.Skipped++
+		}
+		summary.Tests = append(summary.Tests, *test)
+	}
+	summary.Total = len(summary.Tests)
+
+	for _, pkg := range packages {
+		summary.Packages = append(summary.Packages, *pkg)
+	}
+
+	a.emitTestEvent(dir, "server", TestEvent{Action: "done"})
+	return summary, nil
+}
+
+// coverProfilePercent computes the share of covered statements in a -coverprofile file
+func coverProfilePercent(path string) float64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return -1
+	}
+
+	// Blocks are "file:start.col,end.col statements count"; a block can be listed more than once
+	blocks := make(map[string][2]int)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		statements, err1 := strconv.Atoi(fields[1])
+		count, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if existing, exists := blocks[fields[0]]; exists && existing[1] > count {
+			count = existing[1]
+		}
+		blocks[fields[0]] = [2]int{statements, count}
+	}
+
+	total, covered := 0, 0
+	for _, block := range blocks {
+		total += block[0]
+		if block[1] > 0 {
+			covered += block[0]
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+	return float64(covered) * 100 / float64(total)
+}
+
+// planetTestTimeout is how long `npm test` may run before it is killed
+const planetTestTimeout = 10 * time.Minute
+
+// runPlanetTests runs `npm test` as a managed process and reads the counts from its output
+func (a *App) runPlanetTests(dir, planetType, planetPath string) (TestRunSummary, error) {
+	processID := testProcessID(dir, planetType)
+	since := a.lastLogSeq(processID)
+
+	cmd := exec.Command("npm", "test")
+	cmd.Dir = planetPath
+	// CI=true keeps Jest and Vitest out of watch mode
+	cmd.Env = append(os.Environ(), "CI=true", "FORCE_COLOR=0")
+
+	proc, err := a.startManagedProcess(processID, cmd)
+	if err != nil {
+		return TestRunSummary{}, fmt.Errorf("❌ Failed to run npm test: %v", err)
+	}
+
+	timedOut := false
+	select {
+	case <-proc.done:
+	case <-time.After(planetTestTimeout):
+		// A test runner stuck in watch mode or on an open handle would otherwise block forever
+		fmt.Println("⚠️ Planet tests timed out after", planetTestTimeout, "for project", dir)
+		a.stopManagedProcess(processID)
+		timedOut = true
+	}
+
+	var output strings.Builder
+	for _, line := range a.getLogBuffer(processID).since(since) {
+		output.WriteString(line.Text + "\n")
+	}
+
+	summary := TestRunSummary{
+		Target:   planetType,
+		Success:  !timedOut && proc.err == nil,
+		Coverage: -1,
+		Packages: []TestPackageResult{},
+		Tests:    []TestCaseResult{},
+		Output:   output.String(),
+	}
+	summary.Passed, summary.Failed, summary.Skipped, summary.Total = parseJSTestCounts(output.String())
+	if timedOut {
+		summary.Output += fmt.Sprintf("❌ Tests timed out after %s\n", planetTestTimeout)
+	}
+
+	a.emitTestEvent(dir, planetType, TestEvent{Action: "done"})
+	return summary, nil
+}
+
+var (
+	ansiEscapeRegex  = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	jsTestLineRegex  = regexp.MustCompile(`^\s*Tests:?\s+(.+)$`)
+	jsTestCountRegex = regexp.MustCompile(`(\d+) (passed|failed|skipped|todo|total)`)
+)
+
+// parseJSTestCounts reads the last "Tests:" summary line, in either Jest's
+// "Tests: 1 failed, 4 passed, 5 total" or Vitest's "Tests  1 failed | 4 passed (5)" form
+func parseJSTestCounts(output string) (passed, failed, skipped, total int) {
+	lines := strings.Split(ansiEscapeRegex.ReplaceAllString(output, ""), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		match := jsTestLineRegex.FindStringSubmatch(lines[i])
+		if match == nil || !jsTestCountRegex.MatchString(match[1]) {
+			continue
+		}
+
+		for _, count := range jsTestCountRegex.FindAllStringSubmatch(match[1], -1) {
+			n, _ := strconv.Atoi(count[1])
+			switch count[2] {
+			case "passed":
+				passed = n
+			case "failed":
+				failed = n
+			case "skipped", "todo":
+				skipped += n
+			case "total":
+				total = n
+			}
+		}
+
+		// Vitest puts the total in parentheses instead of "N total"
+		if total == 0 {
+			if open := strings.LastIndex(match[1], "("); open >= 0 {
+				total, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(match[1][open+1:]), ")"))
+			}
+		}
+		if total == 0 {
+			total = passed + failed + skipped
+		}
+		return
+	}
+	return
+}
+
+func (a *App) emitTestEvent(dir, target string, event TestEvent) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "tests:"+dir+":"+target, event)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TestEvent is pushed on "tests:<dir>:<target>" while a test run is in progress
+type TestEvent struct {
+	Action  string  `json:"action"` // "run" | "pass" | "fail" | "skip" | "done"
+	Package string  `json:"package"`
+	Test    string  `json:"test"`
+	Elapsed float64 `json:"elapsed"` // Seconds
+	Output  string  `json:"output"`
+}
+
+// TestCaseResult is the outcome of a single test
+type TestCaseResult struct {
+	Package string  `json:"package"`
+	Name    string  `json:"name"`
+	Status  string  `json:"status"` // "pass" | "fail" | "skip"
+	Elapsed float64 `json:"elapsed"`
+	Output  string  `json:"output"`
+}
+
+// TestPackageResult is the outcome of a Go package
+type TestPackageResult struct {
+	Package  string  `json:"package"`
+	Status   string  `json:"status"`
+	Elapsed  float64 `json:"elapsed"`
+	Coverage float64 `json:"coverage"` // Percent of statements, -1 when not reported
+	Output   string  `json:"output"`
+}
+
+// TestRunSummary is the outcome of a whole test run
+type TestRunSummary struct {
+	Target      string              `json:"target"` // "server" or a planet type
+	Success     bool                `json:"success"`
+	Passed      int                 `json:"passed"`
+	Failed      int                 `json:"failed"`
+	Skipped     int                 `json:"skipped"`
+	Total       int                 `json:"total"`
+	Coverage    float64             `json:"coverage"` // Percent of statements across packages, -1 when unknown
+	Packages    []TestPackageResult `json:"packages"`
+	Tests       []TestCaseResult    `json:"tests"`
+	Diagnostics []BuildDiagnostic   `json:"diagnostics"` // Compile errors that kept tests from running
+	Output      string              `json:"output"`
+	Duration    int64               `json:"duration"` // Milliseconds
+}
+
+// RunServerTests runs `go test -json ./...` in the solar system's server, optionally limited
+// to tests matching pattern. Every test start/result is pushed as a TestEvent on
+// "tests:<dir>:server" and the raw output goes to the "test:<dir>:server" log.
+func (a *App) RunServerTests(dir, pattern string) (TestRunSummary, error) {
+	projectRoot := filepath.Join(getSolarDir(a.ProjectsDir), dir)
+	projectPath := filepath.Join(projectRoot, dir+"-star")
+
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return TestRunSummary{}, fmt.Errorf("❌ Project directory does not exist: %s", projectPath)
+	}
+
+	coverProfile := filepath.Join(getGenesisDir(projectRoot), "coverage.out")
+
+	args := []string{"test", "-json", "-coverprofile", coverProfile}
+	if pattern != "" {
+		args = append(args, "-run", pattern)
+	}
+	args = append(args, "./...")
+
+	fmt.Println("🧪 Running server tests for project", dir)
+	a.ClearLogs(testProcessID(dir, "server"))
+	summary, err := a.runGoTests(dir, projectPath, args, coverProfile)
+	if err != nil {
+		return summary, err
+	}
+
+	fmt.Printf("✅ Server tests finished: %d passed, %d failed, %d skipped\n", summary.Passed, summary.Failed, summary.Skipped)
+	return summary, nil
+}
+
+// RunPlanetTests runs the "test" script from a planet's package.json. Output streams on the
+// "test:<dir>:<planet>" log; pass/fail/skip counts are read from the Jest or Vitest summary.
+func (a *App) RunPlanetTests(dir, planetType string) (TestRunSummary, error) {
+	_, planetPath, err := a.resolvePlanet(dir, planetType)
+	if err != nil {
+		return TestRunSummary{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(planetPath, "package.json"))
+	if err != nil {
+		return TestRunSummary{}, fmt.Errorf("❌ Failed to read package.json: %v", err)
+	}
+
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return TestRunSummary{}, fmt.Errorf("❌ Failed to parse package.json: %v", err)
+	}
+	if pkg.Scripts["test"] == "" {
+		return TestRunSummary{}, fmt.Errorf("❌ Planet '%s' has no test script in package.json", planetType)
+	}
+
+	fmt.Println("🧪 Running", planetType, "tests for project", dir)
+	a.ClearLogs(testProcessID(dir, planetType))
+	started := time.Now()
+	summary, err := a.runPlanetTests(dir, planetType, planetPath)
+	if err != nil {
+		return summary, err
+	}
+	summary.Duration = time.Since(started).Milliseconds()
+
+	fmt.Printf("✅ %s tests finished: %d passed, %d failed, %d skipped\n", planetType, summary.Passed, summary.Failed, summary.Skipped)
+	return summary, nil
+}